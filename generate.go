@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/mrparkers/terraform-provider-keycloak/provider"
+)
+
+const generateUsage = `Usage: terraform-provider-keycloak generate -realm <realm> [-output-dir <dir>]
+
+Reads a realm and writes Terraform configuration for it, with an import block for every resource. The provider
+settings are read from the same environment variables that the provider uses, such as KEYCLOAK_URL,
+KEYCLOAK_CLIENT_ID and KEYCLOAK_CLIENT_SECRET.
+
+`
+
+// generate runs the generate subcommand, which writes the configuration of a realm to .tf files
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), generateUsage)
+		flags.PrintDefaults()
+	}
+
+	realm := flags.String("realm", "", "the realm to generate configuration for")
+	outputDir := flags.String("output-dir", ".", "the directory to write the .tf files to, existing files are never overwritten")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *realm == "" {
+		flags.Usage()
+		return fmt.Errorf("-realm is required")
+	}
+
+	ctx := context.Background()
+
+	keycloakProvider := provider.KeycloakProvider(nil)
+	diags := keycloakProvider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"cache_reads": true,
+		// generating configuration never changes the realm
+		"read_only": true,
+	}))
+	for _, d := range diags {
+		// warnings, such as deprecated attributes, don't keep the provider from being configured
+		if d.Severity == diag.Error {
+			return fmt.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	files, err := provider.GenerateConfiguration(ctx, keycloakProvider.Meta().(*keycloak.KeycloakClient), *realm)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(*outputDir, 0755); err != nil {
+		return err
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// check every file first, so nothing is written when one of them is in the way
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(*outputDir, name)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(*outputDir, name))
+		}
+	}
+
+	for _, name := range names {
+		path := filepath.Join(*outputDir, name)
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+
+		_, err = file.Write(files[name])
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(path)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func newGenerateTestRealm(t *testing.T) {
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer(t)
+	t.Setenv("KEYCLOAK_URL", server.URL)
+	t.Setenv("KEYCLOAK_CLIENT_ID", server.ClientId)
+	t.Setenv("KEYCLOAK_CLIENT_SECRET", server.ClientSecret)
+	t.Setenv("KEYCLOAK_REALM", "master")
+	t.Setenv("KEYCLOAK_USER", "")
+	t.Setenv("KEYCLOAK_PASSWORD", "")
+
+	keycloakClient, err := keycloak.NewKeycloakClient(ctx, server.URL, "", server.ClientId, server.ClientSecret, "master", "", "", true, 5, "", false, "", false, nil, keycloak.KeycloakClientOptions{})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	steps := []func() error{
+		func() error {
+			return keycloakClient.NewRealm(ctx, &keycloak.Realm{Realm: "test", Enabled: true})
+		},
+		func() error {
+			client := &keycloak.OpenidClient{RealmId: "test", ClientId: "my-app", Enabled: true, PublicClient: true}
+			if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+				return err
+			}
+
+			if err := keycloakClient.NewGenericProtocolMapper(ctx, &keycloak.GenericProtocolMapper{RealmId: "test", ClientId: client.Id, Name: "audience", Protocol: "openid-connect", ProtocolMapper: "oidc-audience-mapper", Config: map[string]string{"included.client.audience": "my-app"}}); err != nil {
+				return err
+			}
+
+			return keycloakClient.CreateRole(ctx, &keycloak.Role{RealmId: "test", ClientId: client.Id, Name: "admin"})
+		},
+		func() error {
+			// built-in clients aren't generated
+			return keycloakClient.NewOpenidClient(ctx, &keycloak.OpenidClient{RealmId: "test", ClientId: "admin-cli", Enabled: true, PublicClient: true})
+		},
+		func() error {
+			return keycloakClient.NewOpenidClientScope(ctx, &keycloak.OpenidClientScope{RealmId: "test", Name: "my-scope"})
+		},
+		func() error {
+			return keycloakClient.CreateRole(ctx, &keycloak.Role{RealmId: "test", Name: "reader"})
+		},
+		func() error {
+			parent := &keycloak.Group{RealmId: "test", Name: "parent"}
+			if err := keycloakClient.NewGroup(ctx, parent); err != nil {
+				return err
+			}
+
+			return keycloakClient.NewGroup(ctx, &keycloak.Group{RealmId: "test", ParentId: parent.Id, Name: "child"})
+		},
+		func() error {
+			return keycloakClient.NewIdentityProvider(ctx, &keycloak.IdentityProvider{Realm: "test", Alias: "partner", ProviderId: "oidc", Enabled: true, FirstBrokerLoginFlowAlias: "my-flow", Config: &keycloak.IdentityProviderConfig{
+				ClientId:         "partner-client",
+				ClientSecret:     "**********",
+				AuthorizationUrl: "https://partner.example.com/auth",
+				TokenUrl:         "https://partner.example.com/token",
+			}})
+		},
+		func() error {
+			ldap := &keycloak.LdapUserFederation{RealmId: "test", Name: "corporate", Enabled: true, EditMode: "READ_ONLY", Vendor: "OTHER", UsernameLDAPAttribute: "cn", RdnLDAPAttribute: "cn", UuidLDAPAttribute: "entryDN", UserObjectClasses: []string{"person"}, ConnectionUrl: "ldap://ldap.example.com", UsersDn: "dc=example,dc=com", BindDn: "cn=admin", BindCredential: "**********", SearchScope: "1", CachePolicy: "DEFAULT"}
+			if err := keycloakClient.NewLdapUserFederation(ctx, "test", ldap); err != nil {
+				return err
+			}
+
+			return keycloakClient.NewLdapFullNameMapper(ctx, &keycloak.LdapFullNameMapper{RealmId: "test", LdapUserFederationId: ldap.Id, Name: "full name", LdapFullNameAttribute: "cn", ReadOnly: true})
+		},
+		func() error {
+			return keycloakClient.NewAuthenticationFlow(ctx, &keycloak.AuthenticationFlow{RealmId: "test", Alias: "my-flow", ProviderId: "basic-flow"})
+		},
+	}
+
+	for _, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+}
+
+func readGeneratedFiles(t *testing.T, dir string) map[string]string {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tf"))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	files := map[string]string{}
+	for _, path := range paths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		files[filepath.Base(path)] = string(contents)
+	}
+
+	return files
+}
+
+func collapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func TestGenerate(t *testing.T) {
+	newGenerateTestRealm(t)
+
+	dir := t.TempDir()
+	if err := generate([]string{"-realm", "test", "-output-dir", dir}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	files := readGeneratedFiles(t, dir)
+
+	expected := map[string][]string{
+		"realm.tf": {
+			`resource "keycloak_realm" "test" {`,
+			`to = keycloak_realm.test`,
+			`id = "test"`,
+		},
+		"clients.tf": {
+			`resource "keycloak_openid_client" "my_app" {`,
+			`realm_id = keycloak_realm.test.id`,
+			`resource "keycloak_generic_protocol_mapper" "my_app_audience" {`,
+			`client_id = keycloak_openid_client.my_app.id`,
+		},
+		"client_scopes.tf": {
+			`resource "keycloak_openid_client_scope" "my_scope" {`,
+		},
+		"roles.tf": {
+			`resource "keycloak_role" "reader" {`,
+			`resource "keycloak_role" "my_app_admin" {`,
+			`client_id = keycloak_openid_client.my_app.id`,
+		},
+		"groups.tf": {
+			`resource "keycloak_group" "parent" {`,
+			`resource "keycloak_group" "parent_child" {`,
+			`parent_id = keycloak_group.parent.id`,
+		},
+		"identity_providers.tf": {
+			`resource "keycloak_oidc_identity_provider" "partner" {`,
+			`client_secret = var.partner_client_secret`,
+			`first_broker_login_flow_alias = keycloak_authentication_flow.my_flow.alias`,
+			`id = "test/partner"`,
+		},
+		"authentication.tf": {
+			`resource "keycloak_authentication_flow" "my_flow" {`,
+		},
+		"ldap.tf": {
+			`resource "keycloak_ldap_user_federation" "corporate" {`,
+			`bind_credential = var.corporate_bind_credential`,
+			`resource "keycloak_ldap_full_name_mapper" "corporate_full_name" {`,
+			`ldap_user_federation_id = keycloak_ldap_user_federation.corporate.id`,
+		},
+		"variables.tf": {
+			`variable "partner_client_secret" {`,
+			`variable "corporate_bind_credential" {`,
+			`sensitive = true`,
+		},
+	}
+
+	for name, snippets := range expected {
+		contents, ok := files[name]
+		if !ok {
+			t.Fatalf("expected %s to be generated, got %v", name, files)
+		}
+
+		for _, snippet := range snippets {
+			// attributes are aligned by their longest name, which depends on which attributes are written
+			if !strings.Contains(collapseSpaces(contents), collapseSpaces(snippet)) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, snippet, contents)
+			}
+		}
+	}
+
+	if strings.Contains(files["clients.tf"], "admin-cli") {
+		t.Errorf("expected built-in clients to be left out, got:\n%s", files["clients.tf"])
+	}
+
+	// the output only depends on the realm
+	again := t.TempDir()
+	if err := generate([]string{"-realm", "test", "-output-dir", again}); err != nil {
+		t.Fatalf("%s", err)
+	}
+	for name, contents := range readGeneratedFiles(t, again) {
+		if files[name] != contents {
+			t.Errorf("expected %s to be the same when it's generated again, got:\n%s\nand:\n%s", name, files[name], contents)
+		}
+	}
+}
+
+func TestGenerateDoesNotOverwriteFiles(t *testing.T) {
+	newGenerateTestRealm(t)
+
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(dir, "roles.tf"), []byte("# mine\n"), 0644); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := generate([]string{"-realm", "test", "-output-dir", dir}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected an error about the existing file, got %v", err)
+	}
+
+	files := readGeneratedFiles(t, dir)
+	if len(files) != 1 || files["roles.tf"] != "# mine\n" {
+		t.Fatalf("expected nothing to be written, got %v", files)
+	}
+}
+
+func TestGenerateReportsConfigurationErrors(t *testing.T) {
+	newGenerateTestRealm(t)
+	t.Setenv("KEYCLOAK_RETRY_WAIT_MIN", "10")
+	t.Setenv("KEYCLOAK_RETRY_WAIT_MAX", "5")
+
+	err := generate([]string{"-realm", "test", "-output-dir", t.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "retry_wait_min (10) must not be greater than retry_wait_max (5)") {
+		t.Fatalf("expected the configuration error, got %v", err)
+	}
+}
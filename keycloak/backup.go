@@ -0,0 +1,207 @@
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Backups are written before realms, clients, identity providers and LDAP user federations are deleted, when the
+// provider is configured with a backup directory. Every backup is a separate file, named after the time it was taken,
+// the realm, and the object, and except for LDAP user federations, it can be restored with a partial import.
+
+var backupFileNameUnsafeCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// BackupRealm writes the partial export of a realm, with its clients, groups and roles, to the backup directory.
+// Keycloak masks secrets in the export.
+func (keycloakClient *KeycloakClient) BackupRealm(ctx context.Context, realmId string) error {
+	if keycloakClient.backupDirectory == "" {
+		return nil
+	}
+
+	export, err := keycloakClient.ExportRealm(ctx, realmId, true, true)
+	if err != nil {
+		if ErrorIs404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return keycloakClient.writeBackup(ctx, realmId, "realm", realmId, export)
+}
+
+// BackupClient writes the representation of a client, with its roles, to the backup directory
+func (keycloakClient *KeycloakClient) BackupClient(ctx context.Context, realmId, id string) error {
+	if keycloakClient.backupDirectory == "" {
+		return nil
+	}
+
+	var client map[string]interface{}
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s", realmId, id), &client, nil)
+	if err != nil {
+		if ErrorIs404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var roles []map[string]interface{}
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/clients/%s/roles", realmId, id), &roles, nil)
+	if err != nil {
+		return err
+	}
+
+	clientId, _ := client["clientId"].(string)
+
+	backup := map[string]interface{}{
+		"clients": []interface{}{client},
+	}
+	if len(roles) != 0 {
+		backup["roles"] = map[string]interface{}{
+			"client": map[string]interface{}{
+				clientId: roles,
+			},
+		}
+	}
+
+	return keycloakClient.writeBackup(ctx, realmId, "client", clientId, backup)
+}
+
+// BackupIdentityProvider writes the representation of an identity provider, with its mappers, to the backup directory
+func (keycloakClient *KeycloakClient) BackupIdentityProvider(ctx context.Context, realmId, alias string) error {
+	if keycloakClient.backupDirectory == "" {
+		return nil
+	}
+
+	var identityProvider map[string]interface{}
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances/%s", realmId, alias), &identityProvider, nil)
+	if err != nil {
+		if ErrorIs404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var mappers []map[string]interface{}
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances/%s/mappers", realmId, alias), &mappers, nil)
+	if err != nil {
+		return err
+	}
+
+	backup := map[string]interface{}{
+		"identityProviders": []interface{}{identityProvider},
+	}
+	if len(mappers) != 0 {
+		backup["identityProviderMappers"] = mappers
+	}
+
+	return keycloakClient.writeBackup(ctx, realmId, "identity-provider", alias, backup)
+}
+
+// BackupLdapUserFederation writes the representation of an LDAP user federation, with its mappers, to the backup
+// directory. Partial imports don't support components, so the backup is laid out like the components of a realm
+// export instead.
+func (keycloakClient *KeycloakClient) BackupLdapUserFederation(ctx context.Context, realmId, id string) error {
+	if keycloakClient.backupDirectory == "" {
+		return nil
+	}
+
+	var ldapUserFederation map[string]interface{}
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components/%s", realmId, id), &ldapUserFederation, nil)
+	if err != nil {
+		if ErrorIs404(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var mappers []map[string]interface{}
+	err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components", realmId), &mappers, map[string]string{
+		"parent": id,
+		"type":   "org.keycloak.storage.ldap.mappers.LDAPStorageMapper",
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(mappers) != 0 {
+		ldapUserFederation["subComponents"] = map[string]interface{}{
+			"org.keycloak.storage.ldap.mappers.LDAPStorageMapper": mappers,
+		}
+	}
+
+	backup := map[string]interface{}{
+		"components": map[string]interface{}{
+			userStorageProviderType: []interface{}{ldapUserFederation},
+		},
+	}
+
+	name, _ := ldapUserFederation["name"].(string)
+
+	return keycloakClient.writeBackup(ctx, realmId, "ldap-user-federation", name, backup)
+}
+
+// writeBackup writes a representation, indented, to a new file in the backup directory. Existing files are never
+// overwritten.
+func (keycloakClient *KeycloakClient) writeBackup(ctx context.Context, realmId, objectType, name string, representation interface{}) error {
+	body, ok := representation.([]byte)
+	if !ok {
+		var err error
+
+		body, err = json.Marshal(representation)
+		if err != nil {
+			return err
+		}
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, body, "", "  "); err != nil {
+		return fmt.Errorf("failed to back up %s %s: %v", objectType, name, err)
+	}
+	indented.WriteString("\n")
+
+	if err := os.MkdirAll(keycloakClient.backupDirectory, 0700); err != nil {
+		return fmt.Errorf("failed to back up %s %s: %v", objectType, name, err)
+	}
+
+	fileName := fmt.Sprintf("%s_%s_%s_%s.json",
+		time.Now().UTC().Format("20060102T150405.000Z"),
+		backupFileNameUnsafeCharacters.ReplaceAllString(realmId, "_"),
+		objectType,
+		backupFileNameUnsafeCharacters.ReplaceAllString(name, "_"),
+	)
+	path := filepath.Join(keycloakClient.backupDirectory, fileName)
+
+	// backups can contain secrets
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to back up %s %s: %v", objectType, name, err)
+	}
+
+	_, err = file.Write(indented.Bytes())
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to back up %s %s: %v", objectType, name, err)
+	}
+
+	tflog.Info(ctx, "Backed up object before deleting it", map[string]interface{}{
+		"type": objectType,
+		"name": name,
+		"path": path,
+	})
+
+	return nil
+}
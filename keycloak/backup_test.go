@@ -0,0 +1,131 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func readBackup(t *testing.T, dir, suffix string) map[string]interface{} {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+suffix))
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(paths) != 1 {
+		t.Fatalf("expected one backup ending with %s, got %v", suffix, paths)
+	}
+
+	info, err := os.Stat(paths[0])
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected %s to only be readable by its owner, got %s", paths[0], info.Mode().Perm())
+	}
+
+	contents, err := ioutil.ReadFile(paths[0])
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	var backup map[string]interface{}
+	if err := json.Unmarshal(contents, &backup); err != nil {
+		t.Fatalf("expected %s to be JSON, got %s", paths[0], err)
+	}
+
+	return backup
+}
+
+func TestBackupBeforeDelete(t *testing.T) {
+	ctx := context.Background()
+
+	keycloakClient := newFakeServerClient(t, keycloaktest.NewServer(t))
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	client := &OpenidClient{RealmId: "test", ClientId: "https://app.example.com", Enabled: true, PublicClient: true}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := keycloakClient.CreateRole(ctx, &Role{RealmId: "test", ClientId: client.Id, Name: "admin"}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := keycloakClient.NewIdentityProvider(ctx, &IdentityProvider{Realm: "test", Alias: "partner", ProviderId: "oidc", Enabled: true, Config: &IdentityProviderConfig{}}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	ldap := &LdapUserFederation{RealmId: "test", Name: "corporate", Enabled: true, EditMode: "READ_ONLY", Vendor: "OTHER", UsernameLDAPAttribute: "cn", RdnLDAPAttribute: "cn", UuidLDAPAttribute: "entryDN", UserObjectClasses: []string{"person"}, ConnectionUrl: "ldap://ldap.example.com", UsersDn: "dc=example,dc=com", SearchScope: "1", CachePolicy: "DEFAULT"}
+	if err := keycloakClient.NewLdapUserFederation(ctx, "test", ldap); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := keycloakClient.NewLdapFullNameMapper(ctx, &LdapFullNameMapper{RealmId: "test", LdapUserFederationId: ldap.Id, Name: "full name", LdapFullNameAttribute: "cn", ReadOnly: true}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	// nothing is written without a backup directory
+	if err := keycloakClient.BackupRealm(ctx, "test"); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "backups")
+	keycloakClient.backupDirectory = dir
+
+	backups := []func() error{
+		func() error { return keycloakClient.BackupRealm(ctx, "test") },
+		func() error { return keycloakClient.BackupClient(ctx, "test", client.Id) },
+		func() error { return keycloakClient.BackupIdentityProvider(ctx, "test", "partner") },
+		func() error { return keycloakClient.BackupLdapUserFederation(ctx, "test", ldap.Id) },
+		// objects that are already gone are skipped
+		func() error { return keycloakClient.BackupClient(ctx, "test", "missing") },
+	}
+	for _, backup := range backups {
+		if err := backup(); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	realm := readBackup(t, dir, "_test_realm_test.json")
+	if realm["realm"] != "test" || len(realm["clients"].([]interface{})) != 1 {
+		t.Errorf("expected the realm to be exported with its clients, got %v", realm)
+	}
+
+	clientBackup := readBackup(t, dir, "_test_client_https_app.example.com.json")
+	if clientBackup["clients"].([]interface{})[0].(map[string]interface{})["id"] != client.Id {
+		t.Errorf("expected the client to be backed up, got %v", clientBackup)
+	}
+	clientRoles := clientBackup["roles"].(map[string]interface{})["client"].(map[string]interface{})["https://app.example.com"].([]interface{})
+	if len(clientRoles) != 1 || clientRoles[0].(map[string]interface{})["name"] != "admin" {
+		t.Errorf("expected the client roles to be backed up, got %v", clientBackup)
+	}
+
+	identityProvider := readBackup(t, dir, "_test_identity-provider_partner.json")
+	if identityProvider["identityProviders"].([]interface{})[0].(map[string]interface{})["alias"] != "partner" {
+		t.Errorf("expected the identity provider to be backed up, got %v", identityProvider)
+	}
+
+	ldapBackup := readBackup(t, dir, "_test_ldap-user-federation_corporate.json")
+	federation := ldapBackup["components"].(map[string]interface{})[userStorageProviderType].([]interface{})[0].(map[string]interface{})
+	mappers := federation["subComponents"].(map[string]interface{})["org.keycloak.storage.ldap.mappers.LDAPStorageMapper"].([]interface{})
+	if federation["name"] != "corporate" || len(mappers) != 1 {
+		t.Errorf("expected the LDAP user federation to be backed up with its mappers, got %v", ldapBackup)
+	}
+
+	paths, _ := filepath.Glob(filepath.Join(dir, "*"))
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".json") {
+			t.Errorf("unexpected file %s", path)
+		}
+	}
+	if len(paths) != 4 {
+		t.Errorf("expected 4 backups, got %v", paths)
+	}
+}
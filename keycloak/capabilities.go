@@ -0,0 +1,149 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-version"
+)
+
+// Capability is a part of the admin API that is only available on some Keycloak servers. Resources should check for
+// capabilities rather than comparing server versions themselves.
+type Capability string
+
+const (
+	CapabilityLdapGroupMapperGroupsPath Capability = "LDAP group mapper groups path"
+	CapabilityDefaultRoles              Capability = "default roles"
+	CapabilityUserProfile               Capability = "declarative user profile"
+	CapabilityClientPolicies            Capability = "client policies"
+	CapabilityGroupChildrenEndpoint     Capability = "paginated group children"
+	CapabilityRealmLocalization         Capability = "realm localization texts"
+	CapabilityAdminPermissionsV1        Capability = "admin fine-grained permissions (v1)"
+	CapabilityAdminPermissionsV2        Capability = "admin fine-grained permissions (v2)"
+)
+
+type capabilityRequirement struct {
+	// the oldest version of Keycloak that has the capability
+	minimumVersion Version
+	// a feature that has to be enabled on the server, if the capability is behind a feature flag
+	feature string
+	// the version of Keycloak from which the feature can no longer be disabled, if any
+	featureAlwaysEnabledSince Version
+}
+
+var capabilities = map[Capability]capabilityRequirement{
+	CapabilityLdapGroupMapperGroupsPath: {
+		minimumVersion: Version_11,
+	},
+	CapabilityDefaultRoles: {
+		minimumVersion: Version_13,
+	},
+	CapabilityUserProfile: {
+		minimumVersion:            Version_14,
+		feature:                   "declarative-user-profile",
+		featureAlwaysEnabledSince: Version_24,
+	},
+	CapabilityClientPolicies: {
+		minimumVersion: Version_15,
+	},
+	CapabilityGroupChildrenEndpoint: {
+		minimumVersion: Version_23,
+	},
+	CapabilityRealmLocalization: {
+		minimumVersion: Version_13,
+	},
+	CapabilityAdminPermissionsV1: {
+		minimumVersion: Version_6,
+		feature:        "admin-fine-grained-authz",
+	},
+	CapabilityAdminPermissionsV2: {
+		minimumVersion: Version_26,
+		feature:        "admin-fine-grained-authz-v2",
+	},
+}
+
+// CapabilityError is returned when the server does not have a capability that is required by a resource.
+type CapabilityError struct {
+	Capability Capability
+	Reason     string
+}
+
+func (e *CapabilityError) Error() string {
+	return fmt.Sprintf("the Keycloak server does not support %s: %s", e.Capability, e.Reason)
+}
+
+func (keycloakClient *KeycloakClient) getServerInfoAndVersion(ctx context.Context) (*ServerInfo, *version.Version, error) {
+	serverVersion, err := keycloakClient.getServerVersion(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keycloakClient.mutex.RLock()
+	defer keycloakClient.mutex.RUnlock()
+
+	return keycloakClient.serverInfo, serverVersion, nil
+}
+
+// RequireCapability returns a CapabilityError describing why the capability is unavailable, or nil if it is available.
+func (keycloakClient *KeycloakClient) RequireCapability(ctx context.Context, capability Capability) error {
+	requirement, ok := capabilities[capability]
+	if !ok {
+		return fmt.Errorf("unknown capability %q", capability)
+	}
+
+	serverInfo, serverVersion, err := keycloakClient.getServerInfoAndVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkCapability(capability, requirement, serverInfo, serverVersion)
+}
+
+// CapabilityIsAvailable reports whether the server has the capability. Errors are only returned when the server could not
+// be queried.
+func (keycloakClient *KeycloakClient) CapabilityIsAvailable(ctx context.Context, capability Capability) (bool, error) {
+	err := keycloakClient.RequireCapability(ctx, capability)
+	if _, ok := err.(*CapabilityError); ok {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func checkCapability(capability Capability, requirement capabilityRequirement, serverInfo *ServerInfo, serverVersion *version.Version) error {
+	if !versionIsAtLeast(serverVersion, requirement.minimumVersion) {
+		return &CapabilityError{
+			Capability: capability,
+			Reason:     fmt.Sprintf("requires Keycloak %s or later, the server is running %s", requirement.minimumVersion, serverVersion),
+		}
+	}
+
+	if requirement.feature == "" {
+		return nil
+	}
+
+	if requirement.featureAlwaysEnabledSince != "" && versionIsAtLeast(serverVersion, requirement.featureAlwaysEnabledSince) {
+		return nil
+	}
+
+	if serverInfo == nil || !serverInfo.FeatureIsEnabled(requirement.feature) {
+		return &CapabilityError{
+			Capability: capability,
+			Reason:     fmt.Sprintf("the %s feature is not enabled on the server", requirement.feature),
+		}
+	}
+
+	return nil
+}
+
+func versionIsAtLeast(serverVersion *version.Version, minimumVersion Version) bool {
+	v, err := version.NewVersion(string(minimumVersion))
+	if err != nil {
+		return false
+	}
+
+	return serverVersion.GreaterThanOrEqual(v)
+}
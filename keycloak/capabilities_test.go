@@ -0,0 +1,76 @@
+package keycloak
+
+import (
+	"testing"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	for serverVersion, expected := range map[string]string{
+		"21.1.2":              "21.1.2",
+		"7.6.0.GA":            "7.6.0",
+		"24.0.3.redhat-00001": "24.0.3",
+		"26.0.0-SNAPSHOT":     "26.0.0",
+	} {
+		v, err := parseServerVersion(serverVersion)
+		if err != nil {
+			t.Fatalf("failed to parse %s: %s", serverVersion, err)
+		}
+
+		if v.String() != expected {
+			t.Fatalf("expected %s to be parsed as %s, got %s", serverVersion, expected, v)
+		}
+	}
+}
+
+func TestCheckCapability(t *testing.T) {
+	legacyServerInfo := &ServerInfo{
+		ProfileInfo: &ProfileInfo{
+			DisabledFeatures: []string{"DECLARATIVE_USER_PROFILE"},
+		},
+	}
+
+	serverInfo := &ServerInfo{
+		Features: []Feature{
+			{Name: "DECLARATIVE_USER_PROFILE", Enabled: false},
+			{Name: "ADMIN_FINE_GRAINED_AUTHZ", Enabled: false},
+			{Name: "ADMIN_FINE_GRAINED_AUTHZ_V2", Enabled: true},
+		},
+	}
+
+	for _, test := range []struct {
+		capability    Capability
+		serverVersion string
+		serverInfo    *ServerInfo
+		available     bool
+	}{
+		{CapabilityDefaultRoles, "12.0.4", legacyServerInfo, false},
+		{CapabilityDefaultRoles, "13.0.0", legacyServerInfo, true},
+		{CapabilityUserProfile, "21.1.2", legacyServerInfo, false},
+		{CapabilityUserProfile, "21.1.2", &ServerInfo{ProfileInfo: &ProfileInfo{}}, true},
+		{CapabilityUserProfile, "22.0.5", serverInfo, false},
+		{CapabilityUserProfile, "24.0.3.redhat-00001", serverInfo, true},
+		{CapabilityGroupChildrenEndpoint, "22.0.5", serverInfo, false},
+		{CapabilityGroupChildrenEndpoint, "23.0.0", serverInfo, true},
+		{CapabilityAdminPermissionsV1, "21.1.2", legacyServerInfo, true},
+		{CapabilityAdminPermissionsV1, "21.1.2", &ServerInfo{ProfileInfo: &ProfileInfo{DisabledFeatures: []string{"ADMIN_FINE_GRAINED_AUTHZ"}}}, false},
+		{CapabilityAdminPermissionsV1, "26.2.0", serverInfo, false},
+		{CapabilityAdminPermissionsV2, "25.0.6", serverInfo, false},
+		{CapabilityAdminPermissionsV2, "26.2.0", serverInfo, true},
+	} {
+		serverVersion, err := parseServerVersion(test.serverVersion)
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		err = checkCapability(test.capability, capabilities[test.capability], test.serverInfo, serverVersion)
+		if test.available && err != nil {
+			t.Errorf("expected %s to be available on %s, got %s", test.capability, test.serverVersion, err)
+		}
+
+		if !test.available {
+			if _, ok := err.(*CapabilityError); !ok {
+				t.Errorf("expected a CapabilityError for %s on %s, got %v", test.capability, test.serverVersion, err)
+			}
+		}
+	}
+}
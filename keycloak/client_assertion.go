@@ -0,0 +1,166 @@
+package keycloak
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+const (
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime = time.Minute
+)
+
+// ClientAssertion signs the JWTs that are used to authenticate the provider's client with the private_key_jwt client
+// authenticator ("Signed JWT" in the admin console) instead of a client secret.
+type ClientAssertion struct {
+	signer    crypto.Signer
+	algorithm string
+	keyId     string
+}
+
+// NewClientAssertion parses a PEM encoded RSA or EC private key. The signing algorithm defaults to RS256 for RSA keys and
+// ES256 for EC keys when it is empty.
+func NewClientAssertion(privateKeyPem, algorithm, keyId string) (*ClientAssertion, error) {
+	block, _ := pem.Decode([]byte(privateKeyPem))
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode PEM block containing the client assertion private key")
+	}
+
+	signer, err := parsePrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key := signer.(type) {
+	case *rsa.PrivateKey:
+		if algorithm == "" {
+			algorithm = "RS256"
+		}
+		if algorithm != "RS256" {
+			return nil, fmt.Errorf("signing algorithm %s cannot be used with an RSA private key", algorithm)
+		}
+	case *ecdsa.PrivateKey:
+		if algorithm == "" {
+			algorithm = "ES256"
+		}
+		if algorithm != "ES256" {
+			return nil, fmt.Errorf("signing algorithm %s cannot be used with an EC private key", algorithm)
+		}
+		if key.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("ES256 requires an EC private key on the P-256 curve")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported client assertion private key type %T", signer)
+	}
+
+	return &ClientAssertion{
+		signer:    signer,
+		algorithm: algorithm,
+		keyId:     keyId,
+	}, nil
+}
+
+func parsePrivateKey(der []byte) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if signer, ok := key.(crypto.Signer); ok {
+			return signer, nil
+		}
+
+		return nil, fmt.Errorf("unsupported client assertion private key type %T", key)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("failed to parse client assertion private key, expected a PKCS #8, PKCS #1 or SEC 1 encoded key")
+}
+
+// Builds a signed JWT for the given client. Keycloak accepts the token endpoint as the audience of the assertion.
+func (clientAssertion *ClientAssertion) sign(clientId, audience string) (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	header := map[string]string{
+		"alg": clientAssertion.algorithm,
+		"typ": "JWT",
+	}
+	if clientAssertion.keyId != "" {
+		header["kid"] = clientAssertion.keyId
+	}
+
+	claims := map[string]interface{}{
+		"iss": clientId,
+		"sub": clientId,
+		"aud": audience,
+		"jti": hex.EncodeToString(jti),
+		"iat": now.Unix(),
+		"exp": now.Add(clientAssertionLifetime).Unix(),
+	}
+
+	encodedHeader, err := encodeJwtSegment(header)
+	if err != nil {
+		return "", err
+	}
+
+	encodedClaims, err := encodeJwtSegment(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := encodedHeader + "." + encodedClaims
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := clientAssertion.signDigest(digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func encodeJwtSegment(segment interface{}) (string, error) {
+	encoded, err := json.Marshal(segment)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+func (clientAssertion *ClientAssertion) signDigest(digest []byte) ([]byte, error) {
+	// JWS uses the raw R || S encoding for ECDSA signatures, rather than the ASN.1 encoding returned by crypto.Signer
+	if key, ok := clientAssertion.signer.(*ecdsa.PrivateKey); ok {
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+		if err != nil {
+			return nil, err
+		}
+
+		signature := make([]byte, 64)
+		r.FillBytes(signature[:32])
+		s.FillBytes(signature[32:])
+
+		return signature, nil
+	}
+
+	return clientAssertion.signer.Sign(rand.Reader, digest, crypto.SHA256)
+}
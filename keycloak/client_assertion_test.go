@@ -0,0 +1,171 @@
+package keycloak
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func encodePrivateKey(t *testing.T, key interface{}) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func decodeAssertion(t *testing.T, assertion string) (map[string]interface{}, map[string]interface{}, []byte, []byte) {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT with three segments, got %q", assertion)
+	}
+
+	var header, claims map[string]interface{}
+	for i, segment := range []*map[string]interface{}{&header, &claims} {
+		decoded, err := base64.RawURLEncoding.DecodeString(parts[i])
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+		if err := json.Unmarshal(decoded, segment); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	return header, claims, signature, digest[:]
+}
+
+func TestClientAssertionRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	clientAssertion, err := NewClientAssertion(encodePrivateKey(t, key), "", "my-key")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	assertion, err := clientAssertion.sign("terraform", "http://localhost:8080/realms/master/protocol/openid-connect/token")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	header, claims, signature, digest := decodeAssertion(t, assertion)
+
+	if header["alg"] != "RS256" || header["kid"] != "my-key" {
+		t.Fatalf("unexpected assertion header %v", header)
+	}
+
+	if claims["iss"] != "terraform" || claims["sub"] != "terraform" || claims["aud"] != "http://localhost:8080/realms/master/protocol/openid-connect/token" {
+		t.Fatalf("unexpected assertion claims %v", claims)
+	}
+
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest, signature); err != nil {
+		t.Fatalf("assertion signature is invalid: %s", err)
+	}
+}
+
+func TestClientAssertionES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	clientAssertion, err := NewClientAssertion(encodePrivateKey(t, key), "", "")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	assertion, err := clientAssertion.sign("terraform", "audience")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	header, _, signature, digest := decodeAssertion(t, assertion)
+
+	if header["alg"] != "ES256" {
+		t.Fatalf("expected ES256, got %v", header["alg"])
+	}
+
+	if _, ok := header["kid"]; ok {
+		t.Fatalf("expected no kid header, got %v", header["kid"])
+	}
+
+	if len(signature) != 64 {
+		t.Fatalf("expected a 64 byte signature, got %d bytes", len(signature))
+	}
+
+	r := new(big.Int).SetBytes(signature[:32])
+	s := new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(&key.PublicKey, digest, r, s) {
+		t.Fatalf("assertion signature is invalid")
+	}
+}
+
+func TestClientAssertionRejectsMismatchedAlgorithm(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := NewClientAssertion(encodePrivateKey(t, key), "RS256", ""); err == nil {
+		t.Fatalf("expected RS256 to be rejected for an EC key")
+	}
+
+	if _, err := NewClientAssertion("not a key", "", ""); err == nil {
+		t.Fatalf("expected an invalid PEM block to be rejected")
+	}
+}
+
+func TestAuthenticationFormDataUsesClientAssertion(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	clientAssertion, err := NewClientAssertion(encodePrivateKey(t, key), "", "")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	keycloakClient := &KeycloakClient{
+		baseUrl: "http://localhost:8080",
+		realm:   "master",
+		clientCredentials: &ClientCredentials{
+			ClientId:  "terraform",
+			GrantType: "client_credentials",
+		},
+		clientAssertion: clientAssertion,
+	}
+
+	formData, err := keycloakClient.getAuthenticationFormData()
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if formData.Get("client_assertion_type") != clientAssertionType || formData.Get("client_assertion") == "" {
+		t.Fatalf("expected a client assertion in the form data, got %v", formData)
+	}
+
+	if _, ok := formData["client_secret"]; ok {
+		t.Fatalf("expected no client secret in the form data")
+	}
+}
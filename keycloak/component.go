@@ -25,7 +25,7 @@ func (component *component) getConfig(val string) string {
 }
 
 func (component *component) getConfigOk(val string) (string, bool) {
-	if v, ok := component.Config[val]; ok {
+	if v, ok := component.Config[val]; ok && len(v) != 0 {
 		return v[0], true
 	}
 
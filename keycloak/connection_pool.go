@@ -0,0 +1,94 @@
+package keycloak
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ConnectionPool controls how many requests are sent to the Keycloak API at the same time, and how many connections are
+// kept open between requests. Terraform's parallelism otherwise decides how many requests are in flight.
+type ConnectionPool struct {
+	// MaxConcurrentRequests is the number of requests that can be in flight at the same time, or zero for no limit
+	MaxConcurrentRequests int
+	MaxIdleConnections    int
+	IdleConnectionTimeout time.Duration
+}
+
+func (pool ConnectionPool) configureTransport(transport *http.Transport) {
+	if pool.MaxIdleConnections > 0 {
+		transport.MaxIdleConns = pool.MaxIdleConnections
+		// every request goes to the same host, so the per host limit of two idle connections would apply otherwise
+		transport.MaxIdleConnsPerHost = pool.MaxIdleConnections
+	}
+
+	transport.IdleConnTimeout = pool.IdleConnectionTimeout
+}
+
+// wrapTransport limits the number of requests in flight, when there is a limit. The limit applies to every attempt
+// separately, so a request that waits to be retried doesn't keep other requests from being sent.
+func (pool ConnectionPool) wrapTransport(transport http.RoundTripper) http.RoundTripper {
+	if pool.MaxConcurrentRequests <= 0 {
+		return transport
+	}
+
+	return &requestSlotTransport{
+		transport: transport,
+		slots:     make(chan struct{}, pool.MaxConcurrentRequests),
+	}
+}
+
+type requestSlotTransport struct {
+	transport http.RoundTripper
+	slots     chan struct{}
+}
+
+// acquire blocks until another request can be sent, and returns the function that frees the slot again
+func (transport *requestSlotTransport) acquire(ctx context.Context) (func(), error) {
+	select {
+	case transport.slots <- struct{}{}:
+		var once sync.Once
+
+		return func() {
+			once.Do(func() {
+				<-transport.slots
+			})
+		}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// RoundTrip holds a slot until the response body is closed, since the connection is in use until then
+func (transport *requestSlotTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	release, err := transport.acquire(request.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := transport.transport.RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	response.Body = &requestSlotBody{
+		ReadCloser: response.Body,
+		release:    release,
+	}
+
+	return response, nil
+}
+
+type requestSlotBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (body *requestSlotBody) Close() error {
+	defer body.release()
+
+	return body.ReadCloser.Close()
+}
@@ -0,0 +1,129 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newConnectionPoolTestClient(t *testing.T, url string, retryPolicy RetryPolicy, connectionPool ConnectionPool) *KeycloakClient {
+	keycloakClient := newRetryTestClient(t, url, retryPolicy)
+
+	httpClient, err := newHttpClient(false, 5, "", "", "", retryPolicy, connectionPool)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	keycloakClient.httpClient = httpClient
+
+	return keycloakClient
+}
+
+func TestConnectionPoolLimitsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	keycloakClient := newConnectionPoolTestClient(t, server.URL, RetryPolicy{}, ConnectionPool{MaxConcurrentRequests: 3})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			var result map[string]interface{}
+			if err := keycloakClient.get(context.Background(), "/realms/foo", &result, nil); err != nil {
+				t.Errorf("%s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 3 {
+		t.Fatalf("expected at most 3 requests in flight, got %d", maxInFlight)
+	}
+}
+
+func TestConnectionPoolReleasesSlotWhileWaitingToRetry(t *testing.T) {
+	var attempts int32
+	firstAttempt := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/admin/realms/retried" && atomic.AddInt32(&attempts, 1) == 1 {
+			close(firstAttempt)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	keycloakClient := newConnectionPoolTestClient(t, server.URL, RetryPolicy{MaxRetries: 1, MinBackoff: time.Second, MaxBackoff: time.Second}, ConnectionPool{MaxConcurrentRequests: 1})
+
+	retried := make(chan error)
+	go func() {
+		var result map[string]interface{}
+		retried <- keycloakClient.get(context.Background(), "/realms/retried", &result, nil)
+	}()
+
+	<-firstAttempt
+
+	// the only slot is free while the first request waits a second to be retried
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	var result map[string]interface{}
+	if err := keycloakClient.get(ctx, "/realms/other", &result, nil); err != nil {
+		t.Fatalf("expected the request to be sent while the other one waits to be retried, got %s", err)
+	}
+
+	if err := <-retried; err != nil {
+		t.Fatalf("%s", err)
+	}
+}
+
+func TestConnectionPoolWaitIsCancelledWithContext(t *testing.T) {
+	transport := ConnectionPool{MaxConcurrentRequests: 1}.wrapTransport(http.DefaultTransport).(*requestSlotTransport)
+
+	release, err := transport.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := transport.acquire(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the wait for a request slot to time out, got %v", err)
+	}
+}
+
+func TestConnectionPoolConfiguresTransport(t *testing.T) {
+	transport := &http.Transport{}
+	ConnectionPool{MaxIdleConnections: 25, IdleConnectionTimeout: time.Minute}.configureTransport(transport)
+
+	if transport.MaxIdleConns != 25 || transport.MaxIdleConnsPerHost != 25 || transport.IdleConnTimeout != time.Minute {
+		t.Fatalf("unexpected transport settings %d, %d, %s", transport.MaxIdleConns, transport.MaxIdleConnsPerHost, transport.IdleConnTimeout)
+	}
+
+	if (ConnectionPool{}).wrapTransport(transport) != transport {
+		t.Fatalf("expected no limit on concurrent requests by default")
+	}
+}
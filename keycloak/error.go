@@ -1,27 +1,145 @@
 package keycloak
 
 import (
-	"github.com/hashicorp/errwrap"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+
+	"github.com/hashicorp/errwrap"
 )
 
 type ApiError struct {
 	Code    int
 	Message string
+
+	// The fields below are parsed from the response body when Keycloak returns a JSON error
+
+	// ErrorMessage is either a human readable message or a message key, such as "error-invalid-email"
+	ErrorMessage string
+	// Field is the name of the field that failed validation, if Keycloak reported one
+	Field  string
+	Params []string
+	// FieldErrors holds the per-attribute errors returned by the user profile validator
+	FieldErrors []*ApiFieldError
+}
+
+type ApiFieldError struct {
+	Field        string
+	ErrorMessage string
+	Params       []string
 }
 
 func (e *ApiError) Error() string {
 	return e.Message
 }
 
-func ErrorIs404(err error) bool {
+// errorRepresentation covers both Keycloak's ErrorRepresentation and the OAuth2 error responses of the token endpoint
+type errorRepresentation struct {
+	ErrorMessage     string                 `json:"errorMessage"`
+	Field            string                 `json:"field"`
+	Params           []interface{}          `json:"params"`
+	Errors           []*errorRepresentation `json:"errors"`
+	Error            string                 `json:"error"`
+	ErrorDescription string                 `json:"error_description"`
+}
+
+func newApiError(code int, message string, responseBody []byte) *ApiError {
+	apiError := &ApiError{
+		Code:    code,
+		Message: message,
+	}
+
+	var representation errorRepresentation
+	if len(responseBody) == 0 || json.Unmarshal(responseBody, &representation) != nil {
+		return apiError
+	}
+
+	apiError.ErrorMessage = representation.message()
+	apiError.Field = representation.Field
+	apiError.Params = stringifyErrorParams(representation.Params)
+
+	for _, fieldError := range representation.Errors {
+		apiError.FieldErrors = append(apiError.FieldErrors, &ApiFieldError{
+			Field:        fieldError.Field,
+			ErrorMessage: fieldError.message(),
+			Params:       stringifyErrorParams(fieldError.Params),
+		})
+	}
+
+	// a single field error is reported the same way as a list with one entry, so callers only need to handle the list
+	if len(apiError.FieldErrors) == 0 && apiError.Field != "" {
+		apiError.FieldErrors = []*ApiFieldError{{
+			Field:        apiError.Field,
+			ErrorMessage: apiError.ErrorMessage,
+			Params:       apiError.Params,
+		}}
+	}
+
+	return apiError
+}
+
+func (representation *errorRepresentation) message() string {
+	if representation.ErrorMessage != "" {
+		return representation.ErrorMessage
+	}
+
+	if representation.ErrorDescription != "" {
+		return representation.ErrorDescription
+	}
+
+	return representation.Error
+}
+
+func stringifyErrorParams(params []interface{}) []string {
+	var result []string
+	for _, param := range params {
+		result = append(result, fmt.Sprint(param))
+	}
+
+	return result
+}
+
+// AsApiError returns the ApiError wrapped by err, if there is one
+func AsApiError(err error) (*ApiError, bool) {
+	var keycloakError *ApiError
+	if errors.As(err, &keycloakError) {
+		return keycloakError, true
+	}
+
 	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
 
-	return ok && keycloakError != nil && keycloakError.Code == http.StatusNotFound
+	return keycloakError, ok && keycloakError != nil
+}
+
+func errorHasCode(err error, code int) bool {
+	keycloakError, ok := AsApiError(err)
+
+	return ok && keycloakError.Code == code
+}
+
+func ErrorIs400(err error) bool {
+	return errorHasCode(err, http.StatusBadRequest)
+}
+
+func ErrorIs401(err error) bool {
+	return errorHasCode(err, http.StatusUnauthorized)
+}
+
+func ErrorIs403(err error) bool {
+	return errorHasCode(err, http.StatusForbidden)
+}
+
+func ErrorIs404(err error) bool {
+	return errorHasCode(err, http.StatusNotFound)
 }
 
 func ErrorIs409(err error) bool {
-	keycloakError, ok := errwrap.GetType(err, &ApiError{}).(*ApiError)
+	return errorHasCode(err, http.StatusConflict)
+}
+
+func ErrorIs5xx(err error) bool {
+	keycloakError, ok := AsApiError(err)
 
-	return ok && keycloakError != nil && keycloakError.Code == http.StatusConflict
+	return ok && keycloakError.Code >= 500 && keycloakError.Code <= 599
 }
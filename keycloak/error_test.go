@@ -0,0 +1,79 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewApiErrorParsesErrorRepresentation(t *testing.T) {
+	apiError := newApiError(http.StatusBadRequest, "error", []byte(`{"errorMessage": "error-invalid-length", "field": "firstName", "params": ["firstName", 1, 255]}`))
+
+	if apiError.ErrorMessage != "error-invalid-length" || apiError.Field != "firstName" {
+		t.Fatalf("unexpected error fields %+v", apiError)
+	}
+
+	if len(apiError.FieldErrors) != 1 || apiError.FieldErrors[0].Field != "firstName" {
+		t.Fatalf("expected a single field error, got %+v", apiError.FieldErrors)
+	}
+
+	if fmt.Sprint(apiError.Params) != "[firstName 1 255]" {
+		t.Fatalf("unexpected params %v", apiError.Params)
+	}
+}
+
+func TestNewApiErrorParsesUserProfileErrors(t *testing.T) {
+	apiError := newApiError(http.StatusBadRequest, "error", []byte(`{"errors": [{"field": "email", "errorMessage": "error-invalid-email", "params": ["email"]}, {"field": "department", "errorMessage": "error-user-attribute-required", "params": ["department"]}]}`))
+
+	if len(apiError.FieldErrors) != 2 || apiError.FieldErrors[1].Field != "department" || apiError.FieldErrors[1].ErrorMessage != "error-user-attribute-required" {
+		t.Fatalf("unexpected field errors %+v", apiError.FieldErrors)
+	}
+}
+
+func TestNewApiErrorParsesOAuthErrors(t *testing.T) {
+	apiError := newApiError(http.StatusUnauthorized, "error", []byte(`{"error": "invalid_client", "error_description": "Invalid client credentials"}`))
+
+	if apiError.ErrorMessage != "Invalid client credentials" || len(apiError.FieldErrors) != 0 {
+		t.Fatalf("unexpected error fields %+v", apiError)
+	}
+
+	if !ErrorIs401(apiError) || ErrorIs403(apiError) {
+		t.Fatalf("expected a 401 error")
+	}
+}
+
+func TestNewApiErrorIgnoresNonJsonBodies(t *testing.T) {
+	apiError := newApiError(http.StatusBadGateway, "error", []byte(`<html>Bad Gateway</html>`))
+
+	if apiError.ErrorMessage != "" || !ErrorIs5xx(apiError) {
+		t.Fatalf("unexpected error %+v", apiError)
+	}
+
+	wrapped := fmt.Errorf("wrapped: %w", apiError)
+	if !ErrorIs5xx(wrapped) || ErrorIs400(errors.New("not an api error")) {
+		t.Fatalf("expected wrapped errors to be unwrapped")
+	}
+}
+
+func TestApiErrorRedactsResponseBody(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errorMessage": "invalid client", "secret": "s3cr3t"}`))
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{})
+
+	err := keycloakClient.get(context.Background(), "/realms/foo/clients/bar", &map[string]interface{}{}, nil)
+	if err == nil {
+		t.Fatalf("expected the request to fail")
+	}
+
+	if strings.Contains(err.Error(), "s3cr3t") || !strings.Contains(err.Error(), "invalid client") {
+		t.Fatalf("expected the secret to be redacted from the error, got %s", err)
+	}
+}
@@ -33,10 +33,11 @@ func unmarshalExtraConfig(data []byte, reflectValue reflect.Value, extraConfig *
 						var sliceQuoted types.KeycloakSliceQuoted
 						var sliceHashDelimited types.KeycloakSliceHashDelimited
 
+						// both are converted to the type of the field, since an empty value parses as either of them
 						if err = json.Unmarshal([]byte(configValue.(string)), &sliceQuoted); err == nil {
-							field.Set(reflect.ValueOf(sliceQuoted))
+							field.Set(reflect.ValueOf(sliceQuoted).Convert(field.Type()))
 						} else if err = sliceHashDelimited.UnmarshalJSON([]byte(configValue.(string))); err == nil {
-							field.Set(reflect.ValueOf(sliceHashDelimited))
+							field.Set(reflect.ValueOf(sliceHashDelimited).Convert(field.Type()))
 						}
 
 					}
@@ -0,0 +1,170 @@
+package keycloak
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func newFakeServerClient(t *testing.T, server *keycloaktest.Server) *KeycloakClient {
+	keycloakClient, err := NewKeycloakClient(context.Background(), server.URL, "", server.ClientId, server.ClientSecret, "master", "", "", true, 5, "", false, "", false, nil, KeycloakClientOptions{})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return keycloakClient
+}
+
+func TestFakeServerLogin(t *testing.T) {
+	server := keycloaktest.NewServer(t)
+	server.Version = "24.0.5"
+
+	keycloakClient := newFakeServerClient(t, server)
+
+	if ok, _ := keycloakClient.VersionIsGreaterThanOrEqualTo(context.Background(), Version_24); !ok {
+		t.Fatalf("expected the fake server version to be used")
+	}
+
+	_, err := NewKeycloakClient(context.Background(), server.URL, "", server.ClientId, "wrong", "master", "", "", true, 5, "", false, "", false, nil, KeycloakClientOptions{})
+	if err == nil {
+		t.Fatalf("expected login with the wrong client secret to fail")
+	}
+}
+
+func TestFakeServerCrud(t *testing.T) {
+	ctx := context.Background()
+	keycloakClient := newFakeServerClient(t, keycloaktest.NewServer(t))
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "test", Enabled: true}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "test"}); !ErrorIs409(err) {
+		t.Fatalf("expected a conflict when creating the realm twice, got %v", err)
+	}
+
+	client := &OpenidClient{RealmId: "test", ClientId: "my-app", Enabled: true}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	clientRole := &Role{RealmId: "test", ClientId: client.Id, Name: "admin"}
+	if err := keycloakClient.CreateRole(ctx, clientRole); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	role, err := keycloakClient.GetRoleByName(ctx, "test", client.Id, "admin")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if role.Id != clientRole.Id || role.ClientId != client.Id {
+		t.Fatalf("expected client role %s of client %s, got %+v", clientRole.Id, client.Id, role)
+	}
+
+	parent := &Group{RealmId: "test", Name: "parent"}
+	if err := keycloakClient.NewGroup(ctx, parent); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	child := &Group{RealmId: "test", ParentId: parent.Id, Name: "child"}
+	if err := keycloakClient.NewGroup(ctx, child); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	group, err := keycloakClient.GetGroup(ctx, "test", child.Id)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if group.Path != "/parent/child" {
+		t.Fatalf("expected the subgroup path to be /parent/child, got %s", group.Path)
+	}
+
+	user := &User{RealmId: "test", Username: "Bob", Enabled: true}
+	if err := keycloakClient.NewUser(ctx, user); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	found, err := keycloakClient.GetUserByUsername(ctx, "test", "bob")
+	if err != nil || found == nil || found.Id != user.Id {
+		t.Fatalf("expected to find user %s, got %v (%v)", user.Id, found, err)
+	}
+
+	organization := &Organization{RealmName: "test", Name: "acme", Domains: []string{"acme.com"}}
+	if err := keycloakClient.NewOrganization(ctx, organization); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.GetOrganization(ctx, "test", organization.Id); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := keycloakClient.DeleteOpenidClient(ctx, "test", client.Id); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.GetRoleByName(ctx, "test", client.Id, "admin"); !ErrorIs404(err) {
+		t.Fatalf("expected the client's roles to be removed with it, got %v", err)
+	}
+
+	if err := keycloakClient.DeleteRealm(ctx, "test"); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.GetGroup(ctx, "test", parent.Id); !ErrorIs404(err) {
+		t.Fatalf("expected a 404 after deleting the realm, got %v", err)
+	}
+}
+
+func TestReadOnlyClientOnlySendsGetRequests(t *testing.T) {
+	ctx := context.Background()
+	server := keycloaktest.NewServer(t)
+
+	keycloakClient, err := NewKeycloakClient(ctx, server.URL, "", server.ClientId, server.ClientSecret, "master", "", "", true, 5, "", false, "", false, nil, KeycloakClientOptions{ReadOnly: true})
+	if err != nil {
+		t.Fatalf("expected logging in to work in read only mode, got %s", err)
+	}
+
+	if _, err := keycloakClient.GetRealm(ctx, "master"); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	err = keycloakClient.NewRealm(ctx, &Realm{Realm: "test", Enabled: true})
+	if err == nil || !strings.Contains(err.Error(), "read_only") {
+		t.Fatalf("expected creating a realm to be refused, got %v", err)
+	}
+
+	if _, err := newFakeServerClient(t, server).GetRealm(ctx, "test"); !ErrorIs404(err) {
+		t.Fatalf("expected the realm not to be created, got %v", err)
+	}
+}
+
+func TestFakeServerLoginRedHatSSO(t *testing.T) {
+	for serverVersion, expected := range map[string]string{
+		"7.4.1.GA":            "9.0.17",
+		"7.6.0.GA":            "18.0.0",
+		"24.0.3.redhat-00001": "24.0.3",
+		"26.0.6.redhat-00001": "26.0.6",
+	} {
+		server := keycloaktest.NewServer(t)
+		server.Version = serverVersion
+
+		keycloakClient, err := NewKeycloakClient(context.Background(), server.URL, "", server.ClientId, server.ClientSecret, "master", "", "", true, 5, "", false, "", true, nil, KeycloakClientOptions{})
+		if err != nil {
+			t.Fatalf("failed to log in to %s: %s", serverVersion, err)
+		}
+
+		if keycloakClient.version.String() != expected {
+			t.Errorf("expected %s to be treated as Keycloak %s, got %s", serverVersion, expected, keycloakClient.version)
+		}
+	}
+
+	server := keycloaktest.NewServer(t)
+	server.Version = "7.3.9.GA"
+
+	_, err := NewKeycloakClient(context.Background(), server.URL, "", server.ClientId, server.ClientSecret, "master", "", "", true, 5, "", false, "", true, nil, KeycloakClientOptions{})
+	if err == nil || !strings.Contains(err.Error(), "unsupported Red Hat SSO version 7.3.9.GA") {
+		t.Fatalf("expected an error for an unsupported Red Hat SSO version, got %v", err)
+	}
+}
@@ -54,6 +54,24 @@ func (keycloakClient *KeycloakClient) GetGenericProtocolMappers(ctx context.Cont
 
 }
 
+// GetGenericProtocolMappersOf returns the protocol mappers of a client, or of a client scope when clientScopeId is set.
+func (keycloakClient *KeycloakClient) GetGenericProtocolMappersOf(ctx context.Context, realmId, clientId, clientScopeId string) ([]*GenericProtocolMapper, error) {
+	var genericProtocolMappers []*GenericProtocolMapper
+
+	err := keycloakClient.get(ctx, protocolMapperPath(realmId, clientId, clientScopeId), &genericProtocolMappers, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, genericProtocolMapper := range genericProtocolMappers {
+		genericProtocolMapper.RealmId = realmId
+		genericProtocolMapper.ClientId = clientId
+		genericProtocolMapper.ClientScopeId = clientScopeId
+	}
+
+	return genericProtocolMappers, nil
+}
+
 func (keycloakClient *KeycloakClient) GetGenericProtocolMapper(ctx context.Context, realmId string, clientId string, clientScopeId string, mapperId string) (*GenericProtocolMapper, error) {
 	var genericProtocolMapper GenericProtocolMapper
 
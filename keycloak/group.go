@@ -2,61 +2,93 @@ package keycloak
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 )
 
 type Group struct {
-	Id          string              `json:"id,omitempty"`
-	RealmId     string              `json:"-"`
-	ParentId    string              `json:"-"`
-	Name        string              `json:"name"`
-	Path        string              `json:"path,omitempty"`
-	SubGroups   []*Group            `json:"subGroups,omitempty"`
-	RealmRoles  []string            `json:"realmRoles,omitempty"`
-	ClientRoles map[string][]string `json:"clientRoles,omitempty"`
-	Attributes  map[string][]string `json:"attributes"`
+	Id            string              `json:"id,omitempty"`
+	RealmId       string              `json:"-"`
+	ParentId      string              `json:"-"`
+	Name          string              `json:"name"`
+	Path          string              `json:"path,omitempty"`
+	SubGroups     []*Group            `json:"subGroups,omitempty"`
+	SubGroupCount *int                `json:"subGroupCount,omitempty"`
+	RealmRoles    []string            `json:"realmRoles,omitempty"`
+	ClientRoles   map[string][]string `json:"clientRoles,omitempty"`
+	Attributes    map[string][]string `json:"attributes"`
+}
+
+const groupPageSize = 100
+
+// UnmarshalJSON reads the ID of the parent that Keycloak returns for subgroups since version 23. It's never sent back,
+// since older versions reject groups with fields they don't know.
+func (group *Group) UnmarshalJSON(data []byte) error {
+	type groupRepresentation Group
+
+	representation := struct {
+		*groupRepresentation
+		ParentId string `json:"parentId"`
+	}{groupRepresentation: (*groupRepresentation)(group)}
+
+	if err := json.Unmarshal(data, &representation); err != nil {
+		return err
+	}
+
+	group.ParentId = representation.ParentId
+
+	return nil
 }
 
 /*
- * There is no way to get a subgroup's parent ID using the Keycloak API (that I know of, PRs are welcome)
- * The best we can do is check subGroup's path with the group's path to figure out what sub-path to follow
- * until we find it.
+ * Keycloak only returns the ID of a subgroup's parent since version 23, so on older versions the parent is looked up
+ * using the parent's path instead, which is the subgroup's path without its own name.
  */
 func (keycloakClient *KeycloakClient) groupParentId(ctx context.Context, group *Group) (string, error) {
-	// Check the path of the group being passed in.
-	// If there is only one group in the path, then this is a top-level group with no parentId
-	if group.Path == "/"+group.Name {
-		return "", nil
+	if group.ParentId != "" {
+		return group.ParentId, nil
 	}
 
-	groups, err := keycloakClient.ListGroupsWithName(ctx, group.RealmId, group.Name)
-	if err != nil {
-		return "", err
+	parentPath := groupParentPath(group)
+	if parentPath == "" {
+		return "", nil
 	}
 
-	var parentGroup Group
-	if parentGroupId, found := findParentGroup(*group, groups, parentGroup); found {
-		return parentGroupId, nil
+	var parent Group
+	err := keycloakClient.get(ctx, groupByPathUrl(group.RealmId, parentPath), &parent, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine parent ID for group with path %s: %v", group.Path, err)
 	}
 
-	// maybe panic here?  this should never happen
-	return "", fmt.Errorf("unable to determine parent ID for group with path %s", group.Path)
+	return parent.Id, nil
 }
 
-func findParentGroup(group Group, ingroups []*Group, parentGroup Group) (string, bool) {
-	for _, grp := range ingroups {
-		if grp.Id == group.Id {
-			return parentGroup.Id, true
+// Since Keycloak 23, slashes in group names are escaped with a ~ in the group's path
+func groupParentPath(group *Group) string {
+	for _, name := range []string{strings.ReplaceAll(group.Name, "/", "~/"), group.Name} {
+		if strings.HasSuffix(group.Path, "/"+name) {
+			return strings.TrimSuffix(group.Path, "/"+name)
 		}
-		if strings.HasPrefix(group.Path, grp.Path+"/") {
+	}
 
-			if parentGroupId, found := findParentGroup(group, grp.SubGroups, *grp); found {
-				return parentGroupId, found
-			}
-		}
+	// groups without a path, or with a path that isn't nested, are treated as top level groups
+	i := strings.LastIndex(group.Path, "/")
+	if i < 1 {
+		return ""
+	}
+
+	return group.Path[:i]
+}
+
+func groupByPathUrl(realmId, path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
 	}
-	return "", false
+
+	return fmt.Sprintf("/realms/%s/group-by-path/%s", realmId, strings.Join(segments, "/"))
 }
 
 func (keycloakClient *KeycloakClient) ValidateGroupMembers(usernames []interface{}) error {
@@ -92,10 +124,36 @@ func (keycloakClient *KeycloakClient) NewGroup(ctx context.Context, group *Group
 	return nil
 }
 
+// GetGroups returns the top level groups of a realm, with all of their subgroups
 func (keycloakClient *KeycloakClient) GetGroups(ctx context.Context, realmId string) ([]*Group, error) {
+	groups, err := keycloakClient.getTopLevelGroups(ctx, realmId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		err := keycloakClient.loadSubGroups(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return groups, nil
+}
+
+func (keycloakClient *KeycloakClient) getTopLevelGroups(ctx context.Context, realmId string, params map[string]string) ([]*Group, error) {
 	var groups []*Group
 
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups", realmId), &groups, nil)
+	err := keycloakClient.getPaginated(ctx, fmt.Sprintf("/realms/%s/groups", realmId), params, groupPageSize, func(body []byte) (int, error) {
+		var page []*Group
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+
+		groups = append(groups, page...)
+
+		return len(page), nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -107,6 +165,61 @@ func (keycloakClient *KeycloakClient) GetGroups(ctx context.Context, realmId str
 	return groups, nil
 }
 
+// GetGroupChildren returns the direct subgroups of a group. Before Keycloak 23, they are part of the group itself.
+func (keycloakClient *KeycloakClient) GetGroupChildren(ctx context.Context, group *Group) ([]*Group, error) {
+	childrenEndpoint, err := keycloakClient.CapabilityIsAvailable(ctx, CapabilityGroupChildrenEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	children := group.SubGroups
+
+	// the children that are returned as part of a group are complete when their count matches
+	if childrenEndpoint && (group.SubGroupCount == nil || *group.SubGroupCount != len(group.SubGroups)) {
+		children = nil
+
+		err := keycloakClient.getPaginated(ctx, fmt.Sprintf("/realms/%s/groups/%s/children", group.RealmId, group.Id), nil, groupPageSize, func(body []byte) (int, error) {
+			var page []*Group
+			if err := json.Unmarshal(body, &page); err != nil {
+				return 0, err
+			}
+
+			children = append(children, page...)
+
+			return len(page), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, child := range children {
+		child.RealmId = group.RealmId
+		child.ParentId = group.Id
+	}
+
+	return children, nil
+}
+
+// loadSubGroups fills in the subgroups of a group and its subgroups, which Keycloak 23 and later leave out of group lists
+func (keycloakClient *KeycloakClient) loadSubGroups(ctx context.Context, group *Group) error {
+	children, err := keycloakClient.GetGroupChildren(ctx, group)
+	if err != nil {
+		return err
+	}
+
+	group.SubGroups = children
+
+	for _, child := range children {
+		err := keycloakClient.loadSubGroups(ctx, child)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (keycloakClient *KeycloakClient) GetGroup(ctx context.Context, realmId, id string) (*Group, error) {
 	var group Group
 
@@ -128,59 +241,145 @@ func (keycloakClient *KeycloakClient) GetGroup(ctx context.Context, realmId, id
 }
 
 func (keycloakClient *KeycloakClient) GetGroupByName(ctx context.Context, realmId, name string) (*Group, error) {
-	var groups []Group
-
 	// We can't get a group by name, so we have to search for it
 	params := map[string]string{
 		"search": name,
 	}
 
-	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/groups", realmId), &groups, params)
+	groups, err := keycloakClient.getTopLevelGroups(ctx, realmId, params)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(groups) == 0 {
+	// The search may return more than 1 result even if there is a group exactly matching the search string
+	group, err := keycloakClient.findGroupByName(ctx, name, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	if group == nil {
 		return nil, fmt.Errorf("no group with name " + name + " found")
 	}
 
-	// The search may return more than 1 result even if there is a group exactly matching the search string
-	groupsPtr := make([]*Group, len(groups))
-	for i := range groups {
-		groupsPtr[i] = &groups[i]
+	parentId, err := keycloakClient.groupParentId(ctx, group)
+	if err != nil {
+		return nil, err
 	}
-	group := getGroupByDFS(name, groupsPtr)
-	if group != nil {
-		group.RealmId = realmId // it's important to set RealmId here because fetching the ParentId depends on it
 
-		parentId, err := keycloakClient.groupParentId(ctx, group)
-		if err != nil {
-			return nil, err
-		}
+	group.ParentId = parentId
 
-		group.ParentId = parentId
+	return group, nil
+}
+
+// GetGroupByPath returns the group with the given path, such as /parent/child
+func (keycloakClient *KeycloakClient) GetGroupByPath(ctx context.Context, realmId, path string) (*Group, error) {
+	var group Group
 
-		return group, nil
+	err := keycloakClient.get(ctx, groupByPathUrl(realmId, path), &group, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("no group with name " + name + " found")
+	group.RealmId = realmId
+
+	parentId, err := keycloakClient.groupParentId(ctx, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	group.ParentId = parentId
+
+	return &group, nil
 }
 
 /*
 Find group by name in groups returned by /groups?search=${group_name}
-If there are multiple groups match the name, it will return the first one it found, using DFS algorithm
+If there are multiple groups match the name, it will return the first one it found, using DFS algorithm. Subgroups that
+weren't returned by the search are fetched page by page.
 */
-func getGroupByDFS(groupName string, groups []*Group) *Group {
+func (keycloakClient *KeycloakClient) findGroupByName(ctx context.Context, name string, groups []*Group) (*Group, error) {
 	for _, group := range groups {
-		if groupName == group.Name {
-			return group
+		if group.Name == name {
+			return group, nil
 		}
-		groupFound := getGroupByDFS(groupName, group.SubGroups)
-		if groupFound != nil {
-			return groupFound
+
+		subGroups := group.SubGroups
+		for _, subGroup := range subGroups {
+			subGroup.RealmId = group.RealmId
+		}
+
+		if len(subGroups) == 0 && (group.SubGroupCount == nil || *group.SubGroupCount > 0) {
+			children, err := keycloakClient.GetGroupChildren(ctx, group)
+			if err != nil {
+				return nil, err
+			}
+
+			subGroups = children
+		}
+
+		found, err := keycloakClient.findGroupByName(ctx, name, subGroups)
+		if err != nil || found != nil {
+			return found, err
 		}
 	}
-	return nil
+
+	return nil, nil
+}
+
+// GetGroupSubtree returns every group below the group with the given path, or every group of the realm when the path is
+// empty, as a flat list in depth first order. maxDepth limits how many levels are returned, or returns all of them when
+// it's zero.
+func (keycloakClient *KeycloakClient) GetGroupSubtree(ctx context.Context, realmId, path string, maxDepth int) ([]*Group, error) {
+	var groups []*Group
+
+	if path == "" || path == "/" {
+		topLevelGroups, err := keycloakClient.getTopLevelGroups(ctx, realmId, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = topLevelGroups
+	} else {
+		root, err := keycloakClient.GetGroupByPath(ctx, realmId, path)
+		if err != nil {
+			return nil, err
+		}
+
+		children, err := keycloakClient.GetGroupChildren(ctx, root)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = children
+	}
+
+	return keycloakClient.flattenGroups(ctx, groups, 1, maxDepth)
+}
+
+func (keycloakClient *KeycloakClient) flattenGroups(ctx context.Context, groups []*Group, depth, maxDepth int) ([]*Group, error) {
+	var flattened []*Group
+
+	for _, group := range groups {
+		flattened = append(flattened, group)
+
+		if maxDepth != 0 && depth >= maxDepth {
+			continue
+		}
+
+		children, err := keycloakClient.GetGroupChildren(ctx, group)
+		if err != nil {
+			return nil, err
+		}
+
+		descendants, err := keycloakClient.flattenGroups(ctx, children, depth+1, maxDepth)
+		if err != nil {
+			return nil, err
+		}
+
+		flattened = append(flattened, descendants...)
+	}
+
+	return flattened, nil
 }
 
 func (keycloakClient *KeycloakClient) UpdateGroup(ctx context.Context, group *Group) error {
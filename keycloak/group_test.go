@@ -0,0 +1,153 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestGroupParentPath(t *testing.T) {
+	tests := []struct {
+		group      Group
+		parentPath string
+	}{
+		{Group{Name: "top", Path: "/top"}, ""},
+		{Group{Name: "child", Path: "/top/child"}, "/top"},
+		{Group{Name: "a/b", Path: "/top/a~/b"}, "/top"},
+		{Group{Name: "a/b", Path: "/top/a/b"}, "/top"},
+		{Group{Name: "top", Path: ""}, ""},
+		{Group{Name: "renamed", Path: "/top"}, ""},
+		{Group{Name: "renamed", Path: "top"}, ""},
+		{Group{Name: "renamed", Path: "/top/child"}, "/top"},
+	}
+
+	for _, test := range tests {
+		if parentPath := groupParentPath(&test.group); parentPath != test.parentPath {
+			t.Errorf("expected the parent of %s to be %q, got %q", test.group.Path, test.parentPath, parentPath)
+		}
+	}
+}
+
+func TestGroupParentId(t *testing.T) {
+	ctx := context.Background()
+
+	var group Group
+	if err := json.Unmarshal([]byte(`{"id": "child", "name": "child", "path": "/top/child", "parentId": "top"}`), &group); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if group.ParentId != "top" || group.Path != "/top/child" {
+		t.Fatalf("expected the parent ID returned by Keycloak to be read, got %+v", group)
+	}
+
+	encoded, err := json.Marshal(&group)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if strings.Contains(string(encoded), "parentId") {
+		t.Fatalf("expected the parent ID not to be sent to Keycloak, got %s", encoded)
+	}
+
+	// the parent ID returned by Keycloak is used without looking the parent up, so a client without a server is enough
+	if parentId, err := (&KeycloakClient{}).groupParentId(ctx, &group); err != nil || parentId != "top" {
+		t.Fatalf("expected the parent ID returned by Keycloak to be used, got %q, %v", parentId, err)
+	}
+
+	// older versions of Keycloak don't return the parent ID, so the parent is looked up by its path
+	keycloakClient := newFakeServerClient(t, keycloaktest.NewServer(t))
+	top := &Group{RealmId: "master", Name: "top"}
+	if err := keycloakClient.NewGroup(ctx, top); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	parentId, err := keycloakClient.groupParentId(ctx, &Group{RealmId: "master", Name: "child", Path: "/top/child"})
+	if err != nil || parentId != top.Id {
+		t.Fatalf("expected the parent to be looked up by its path, got %q, %v", parentId, err)
+	}
+}
+
+func newGroupTree(t *testing.T, keycloakClient *KeycloakClient) (*Group, *Group) {
+	ctx := context.Background()
+
+	top := &Group{RealmId: "master", Name: "top"}
+	if err := keycloakClient.NewGroup(ctx, top); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	// more children than fit on a page
+	var middle *Group
+	for i := 0; i < groupPageSize+5; i++ {
+		child := &Group{RealmId: "master", ParentId: top.Id, Name: fmt.Sprintf("child-%03d", i)}
+		if err := keycloakClient.NewGroup(ctx, child); err != nil {
+			t.Fatalf("%s", err)
+		}
+		middle = child
+	}
+
+	deep := &Group{RealmId: "master", ParentId: middle.Id, Name: "deep"}
+	if err := keycloakClient.NewGroup(ctx, deep); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return top, deep
+}
+
+func TestGroupLookupFindsDeepGroups(t *testing.T) {
+	for _, serverVersion := range []string{"22.0.5", "26.0.0"} {
+		t.Run(serverVersion, func(t *testing.T) {
+			ctx := context.Background()
+
+			server := keycloaktest.NewServer(t)
+			server.Version = serverVersion
+			keycloakClient := newFakeServerClient(t, server)
+
+			top, deep := newGroupTree(t, keycloakClient)
+
+			group, err := keycloakClient.GetGroupByName(ctx, "master", "deep")
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if group.Id != deep.Id || group.ParentId != deep.ParentId {
+				t.Fatalf("expected group %s with parent %s, got %s with parent %s", deep.Id, deep.ParentId, group.Id, group.ParentId)
+			}
+
+			group, err = keycloakClient.GetGroupByPath(ctx, "master", fmt.Sprintf("/top/child-%03d/deep", groupPageSize+4))
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if group.Id != deep.Id || group.ParentId != deep.ParentId {
+				t.Fatalf("expected group %s with parent %s, got %s with parent %s", deep.Id, deep.ParentId, group.Id, group.ParentId)
+			}
+
+			groups, err := keycloakClient.GetGroups(ctx, "master")
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if len(groups) != 1 || len(groups[0].SubGroups) != groupPageSize+5 {
+				t.Fatalf("expected one top level group with %d subgroups", groupPageSize+5)
+			}
+
+			subtree, err := keycloakClient.GetGroupSubtree(ctx, "master", "", 0)
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if len(subtree) != groupPageSize+7 {
+				t.Fatalf("expected %d groups, got %d", groupPageSize+7, len(subtree))
+			}
+			if last := subtree[len(subtree)-1]; last.Id != deep.Id || last.ParentId != deep.ParentId {
+				t.Fatalf("expected the deep group to be last, with its parent id, got %+v", last)
+			}
+
+			subtree, err = keycloakClient.GetGroupSubtree(ctx, "master", "/top", 1)
+			if err != nil {
+				t.Fatalf("%s", err)
+			}
+			if len(subtree) != groupPageSize+5 || subtree[0].ParentId != top.Id {
+				t.Fatalf("expected only the direct children of the top group, got %d groups", len(subtree))
+			}
+		})
+	}
+}
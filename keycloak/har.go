@@ -0,0 +1,327 @@
+package keycloak
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Requests and responses sent through sendRequest can be recorded to an HTTP Archive (HAR) file, which can be replayed
+// later without a Keycloak server. Bodies are redacted the same way they are when they're logged, and credentials are
+// never recorded, since authentication headers and token requests are left out of the archive.
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string      `json:"version"`
+	Creator harCreator  `json:"creator"`
+	Entries []*harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            int64       `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	Url         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    int64 `json:"send"`
+	Wait    int64 `json:"wait"`
+	Receive int64 `json:"receive"`
+}
+
+// Only these headers are recorded, the others either hold credentials or don't matter when the archive is replayed
+var harRecordedHeaders = []string{"Content-Type", "Location"}
+
+type harRecorder struct {
+	path string
+	file *os.File
+	// offset is where the end of the archive, which is overwritten by the next entry, starts
+	offset  int64
+	entries int
+	mutex   sync.Mutex
+}
+
+func newHarRecorder(path string) *harRecorder {
+	return &harRecorder{
+		path: path,
+	}
+}
+
+func harHeaders(header http.Header) []harNameValue {
+	headers := []harNameValue{}
+	for _, name := range harRecordedHeaders {
+		if value := header.Get(name); value != "" {
+			headers = append(headers, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	return headers
+}
+
+// record adds an exchange to the archive. The provider isn't told when Terraform is done with it, so the file is kept
+// open and every entry is written over the end of the archive, followed by a new end, to keep the file valid JSON.
+func (recorder *harRecorder) record(request *http.Request, requestBody []byte, response *http.Response, responseBody []byte, started time.Time, redactor *logRedactor) error {
+	queryString := []harNameValue{}
+	for name, values := range request.URL.Query() {
+		for _, value := range values {
+			queryString = append(queryString, harNameValue{Name: name, Value: value})
+		}
+	}
+
+	entry := &harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            time.Since(started).Milliseconds(),
+		Request: harRequest{
+			Method:      request.Method,
+			Url:         request.URL.String(),
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(request.Header),
+			QueryString: queryString,
+			HeadersSize: -1,
+			BodySize:    len(requestBody),
+		},
+		Response: harResponse{
+			Status:      response.StatusCode,
+			StatusText:  http.StatusText(response.StatusCode),
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(response.Header),
+			Content: harContent{
+				Size:     len(responseBody),
+				MimeType: response.Header.Get("Content-Type"),
+			},
+			RedirectURL: response.Header.Get("Location"),
+			HeadersSize: -1,
+			BodySize:    len(responseBody),
+		},
+		Timings: harTimings{
+			Wait: time.Since(started).Milliseconds(),
+		},
+	}
+
+	if requestBody != nil {
+		entry.Request.PostData = &harPostData{
+			MimeType: request.Header.Get("Content-Type"),
+			Text:     redactor.redactBody(requestBody),
+		}
+	}
+
+	if len(responseBody) != 0 {
+		entry.Response.Content.Text = redactor.redactBody(responseBody)
+	}
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	if recorder.file == nil {
+		if err := recorder.open(); err != nil {
+			return err
+		}
+	}
+
+	if recorder.entries != 0 {
+		encoded = append([]byte(",\n"), encoded...)
+	}
+	encoded = append(encoded, harEnd...)
+
+	if _, err := recorder.file.WriteAt(encoded, recorder.offset); err != nil {
+		return err
+	}
+
+	recorder.offset += int64(len(encoded) - len(harEnd))
+	recorder.entries++
+
+	return nil
+}
+
+var harEnd = []byte("\n]}}\n")
+
+// open creates the archive, replacing any previous recording, and writes everything up to its first entry
+func (recorder *harRecorder) open() error {
+	file, err := os.OpenFile(recorder.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	creator, err := json.Marshal(harCreator{
+		Name:    "terraform-provider-keycloak",
+		Version: "1.0",
+	})
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	start := []byte(fmt.Sprintf(`{"log":{"version":"1.2","creator":%s,"entries":[`+"\n", creator))
+	if _, err := file.Write(append(start, harEnd...)); err != nil {
+		file.Close()
+		return err
+	}
+
+	recorder.file = file
+	recorder.offset = int64(len(start))
+
+	return nil
+}
+
+type harReplayTransport struct {
+	entries []*harEntry
+	used    map[*harEntry]bool
+	mutex   sync.Mutex
+}
+
+// NewHarReplayTransport serves the responses recorded in a HAR file. Requests are matched on their method, path and
+// query, ignoring the host, and recorded responses are served in order. Once every matching response was served, the
+// last one is repeated. Token requests are answered with a placeholder token, since they are never recorded.
+func NewHarReplayTransport(path string) (http.RoundTripper, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var har harFile
+	if err := json.Unmarshal(contents, &har); err != nil {
+		return nil, fmt.Errorf("failed to parse HAR file %s: %v", path, err)
+	}
+
+	return &harReplayTransport{
+		entries: har.Log.Entries,
+		used:    map[*harEntry]bool{},
+	}, nil
+}
+
+func harRequestKey(method, requestUrl string) string {
+	key := requestUrl
+	if i := strings.Index(key, "://"); i != -1 {
+		key = key[i+3:]
+		if j := strings.Index(key, "/"); j != -1 {
+			key = key[j:]
+		} else {
+			key = "/"
+		}
+	}
+
+	return method + " " + key
+}
+
+func (transport *harReplayTransport) findEntry(request *http.Request) *harEntry {
+	key := harRequestKey(request.Method, request.URL.String())
+
+	transport.mutex.Lock()
+	defer transport.mutex.Unlock()
+
+	var last *harEntry
+	for _, entry := range transport.entries {
+		if harRequestKey(entry.Request.Method, entry.Request.Url) != key {
+			continue
+		}
+
+		if !transport.used[entry] {
+			transport.used[entry] = true
+			return entry
+		}
+
+		last = entry
+	}
+
+	return last
+}
+
+func (transport *harReplayTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Body != nil {
+		request.Body.Close()
+	}
+
+	entry := transport.findEntry(request)
+	if entry == nil {
+		if request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/protocol/openid-connect/token") {
+			return newHarReplayResponse(request, http.StatusOK, http.Header{"Content-Type": []string{"application/json"}}, `{"access_token":"replay","token_type":"Bearer","expires_in":3600}`), nil
+		}
+
+		return nil, fmt.Errorf("no recorded response for %s %s", request.Method, request.URL.RequestURI())
+	}
+
+	header := http.Header{}
+	for _, h := range entry.Response.Headers {
+		header.Add(h.Name, h.Value)
+	}
+
+	return newHarReplayResponse(request, entry.Response.Status, header, entry.Response.Content.Text), nil
+}
+
+func newHarReplayResponse(request *http.Request, statusCode int, header http.Header, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", statusCode, http.StatusText(statusCode)),
+		StatusCode:    statusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}
+}
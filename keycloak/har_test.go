@@ -0,0 +1,128 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHarRecordingCanBeReplayed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/realms/foo/users":
+			w.Header().Set("Location", "/admin/realms/foo/users/123")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/realms/foo/users/123":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id":"123","username":"bob"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	harFile := filepath.Join(t.TempDir(), "keycloak.har")
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{})
+	keycloakClient.harRecorder = newHarRecorder(harFile)
+
+	ctx := context.Background()
+
+	_, location, err := keycloakClient.post(ctx, "/realms/foo/users", map[string]interface{}{
+		"username":    "bob",
+		"credentials": []map[string]interface{}{{"type": "password", "value": "hunter2"}},
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	var user map[string]interface{}
+	if err := keycloakClient.get(ctx, "/realms/foo/users/123", &user, nil); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.getRaw(ctx, "/realms/foo/users/456", nil); !ErrorIs404(err) {
+		t.Fatalf("expected a 404, got %v", err)
+	}
+
+	recording, err := ioutil.ReadFile(harFile)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if strings.Contains(string(recording), "hunter2") || strings.Contains(string(recording), "Bearer") {
+		t.Fatalf("expected credentials to be left out of the recording, got %s", recording)
+	}
+
+	var archive struct {
+		Log harLog `json:"log"`
+	}
+	if err := json.Unmarshal(recording, &archive); err != nil {
+		t.Fatalf("expected the recording to be valid JSON, got %s", err)
+	}
+
+	if len(archive.Log.Entries) != 3 || archive.Log.Version != "1.2" {
+		t.Fatalf("expected 3 recorded entries, got %d", len(archive.Log.Entries))
+	}
+
+	server.Close()
+
+	replayTransport, err := NewHarReplayTransport(harFile)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	replayClient := newRetryTestClient(t, "http://replay.invalid", RetryPolicy{})
+	replayClient.httpClient = &http.Client{Transport: replayTransport}
+
+	_, replayedLocation, err := replayClient.post(ctx, "/realms/foo/users", map[string]interface{}{"username": "bob"})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if replayedLocation != location {
+		t.Fatalf("expected location %s, got %s", location, replayedLocation)
+	}
+
+	var replayedUser map[string]interface{}
+	if err := replayClient.get(ctx, "/realms/foo/users/123", &replayedUser, nil); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if replayedUser["username"] != "bob" {
+		t.Fatalf("expected the recorded user, got %v", replayedUser)
+	}
+
+	if _, err := replayClient.getRaw(ctx, "/realms/foo/users/456", nil); !ErrorIs404(err) {
+		t.Fatalf("expected the recorded 404, got %v", err)
+	}
+
+	if _, err := replayClient.getRaw(ctx, "/realms/foo/groups", nil); err == nil {
+		t.Fatalf("expected an error for a request that wasn't recorded")
+	}
+}
+
+func TestHarReplayTransportServesTokens(t *testing.T) {
+	harFile := filepath.Join(t.TempDir(), "keycloak.har")
+	if err := ioutil.WriteFile(harFile, []byte(`{"log":{"version":"1.2","entries":[{"request":{"method":"GET","url":"http://localhost:8080/admin/serverinfo"},"response":{"status":200,"content":{"text":"{\"systemInfo\":{\"version\":\"26.0.0\"}}"}}}]}}`), 0600); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), "http://replay.invalid", "", "terraform", "secret", "master", "", "", false, 5, "", false, "", false, nil, KeycloakClientOptions{HarReplayFile: harFile})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := keycloakClient.login(context.Background()); err != nil {
+		t.Fatalf("expected login to succeed against the replay transport: %s", err)
+	}
+
+	if keycloakClient.clientCredentials.AccessToken != "replay" {
+		t.Fatalf("expected the placeholder token, got %s", keycloakClient.clientCredentials.AccessToken)
+	}
+}
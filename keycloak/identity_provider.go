@@ -92,6 +92,21 @@ func (keycloakClient *KeycloakClient) GetIdentityProvider(ctx context.Context, r
 	return &identityProvider, nil
 }
 
+func (keycloakClient *KeycloakClient) GetIdentityProviders(ctx context.Context, realm string) ([]*IdentityProvider, error) {
+	var identityProviders []*IdentityProvider
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances", realm), &identityProviders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityProvider := range identityProviders {
+		identityProvider.Realm = realm
+	}
+
+	return identityProviders, nil
+}
+
 func (keycloakClient *KeycloakClient) UpdateIdentityProvider(ctx context.Context, identityProvider *IdentityProvider) error {
 	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/identity-provider/instances/%s", identityProvider.Realm, identityProvider.Alias), identityProvider)
 }
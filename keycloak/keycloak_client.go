@@ -12,7 +12,9 @@ import (
 	"net/http/cookiejar"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -20,21 +22,30 @@ import (
 	"github.com/hashicorp/go-version"
 
 	"golang.org/x/net/publicsuffix"
-
-	"github.com/hashicorp/go-retryablehttp"
 )
 
 type KeycloakClient struct {
 	baseUrl           string
 	realm             string
 	clientCredentials *ClientCredentials
-	httpClient        *http.Client
-	initialLogin      bool
-	userAgent         string
-	version           *version.Version
-	additionalHeaders map[string]string
-	debug             bool
-	redHatSSO         bool
+	clientAssertion   *ClientAssertion
+	// set when the provider was given an access token, or a command that prints one, instead of credentials
+	accessTokenCommand []string
+	staticAccessToken  bool
+	httpClient         *http.Client
+	initialLogin       bool
+	userAgent          string
+	version            *version.Version
+	serverInfo         *ServerInfo
+	additionalHeaders  map[string]string
+	debug              bool
+	logRedactor        *logRedactor
+	harRecorder        *harRecorder
+	readCache          *readCache
+	readOnly           bool
+	backupDirectory    string
+	redHatSSO          bool
+	mutex              sync.RWMutex
 }
 
 type ClientCredentials struct {
@@ -46,6 +57,11 @@ type ClientCredentials struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 	TokenType    string `json:"token_type"`
+
+	ExpiresIn          int `json:"expires_in"`
+	RefreshExpiresIn   int `json:"refresh_expires_in"`
+	accessTokenExpiry  time.Time
+	refreshTokenExpiry time.Time
 }
 
 const (
@@ -60,39 +76,103 @@ var redHatSSO7VersionMap = map[int]string{
 	4: "9.0.17",
 }
 
-func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecret, realm, username, password string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, userAgent string, redHatSSO bool, additionalHeaders map[string]string) (*KeycloakClient, error) {
+// KeycloakClientOptions holds the optional settings of a KeycloakClient. Its zero value keeps the default behavior.
+type KeycloakClientOptions struct {
+	RetryPolicy RetryPolicy
+	// authenticates the client with a signed JWT instead of its secret
+	ClientAssertion *ClientAssertion
+	// PEM encoded certificate and private key that the client authenticates with using mutual TLS
+	TlsClientCertificate string
+	TlsClientKey         string
+	// an access token to use instead of logging in, or a command that prints one whenever a new token is needed
+	AccessToken        string
+	AccessTokenCommand []string
+	// fields whose values are masked in the debug logs, in addition to secrets and tokens
+	RedactedLogFields []string
+	// a file that the requests to Keycloak are recorded to, or replayed from, in the HAR format
+	HarRecordFile  string
+	HarReplayFile  string
+	ConnectionPool ConnectionPool
+	// caches the responses to GET requests for as long as the client is used
+	CacheReads bool
+	// fails every request that would change something in Keycloak
+	ReadOnly bool
+	// the directory that objects are backed up to before they are deleted
+	BackupDirectory string
+}
+
+func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecret, realm, username, password string, initialLogin bool, clientTimeout int, caCert string, tlsInsecureSkipVerify bool, userAgent string, redHatSSO bool, additionalHeaders map[string]string, options KeycloakClientOptions) (*KeycloakClient, error) {
 	clientCredentials := &ClientCredentials{
 		ClientId:     clientId,
 		ClientSecret: clientSecret,
 	}
-	if password != "" && username != "" {
+	if options.AccessToken != "" || len(options.AccessTokenCommand) != 0 {
+		tflog.Debug(ctx, "using an externally provided access token, skipping login")
+	} else if clientId == "" {
+		if initialLogin {
+			return nil, fmt.Errorf("must specify client id, or an access token")
+		} else {
+			tflog.Warn(ctx, "missing required keycloak client id, but proceeding anyways as initial_login is false")
+		}
+	} else if password != "" && username != "" {
 		clientCredentials.Username = username
 		clientCredentials.Password = password
 		clientCredentials.GrantType = "password"
-	} else if clientSecret != "" {
+	} else if clientSecret != "" || options.ClientAssertion != nil || options.TlsClientCertificate != "" {
 		clientCredentials.GrantType = "client_credentials"
 	} else {
 		if initialLogin {
-			return nil, fmt.Errorf("must specify client id, username and password for password grant, or client id and secret for client credentials grant")
+			return nil, fmt.Errorf("must specify client id, username and password for password grant, client id and either a secret, a client assertion key or a client certificate for client credentials grant, or an access token")
 		} else {
 			tflog.Warn(ctx, "missing required keycloak credentials, but proceeding anyways as initial_login is false")
 		}
 	}
 
-	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert)
+	httpClient, err := newHttpClient(tlsInsecureSkipVerify, clientTimeout, caCert, options.TlsClientCertificate, options.TlsClientKey, options.RetryPolicy, options.ConnectionPool)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create http client: %v", err)
 	}
 
+	if options.HarReplayFile != "" {
+		replayTransport, err := NewHarReplayTransport(options.HarReplayFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load HAR file to replay: %v", err)
+		}
+
+		httpClient = &http.Client{
+			Transport: replayTransport,
+			Jar:       httpClient.Jar,
+		}
+	}
+
 	keycloakClient := KeycloakClient{
 		baseUrl:           url + basePath,
 		clientCredentials: clientCredentials,
+		clientAssertion:   options.ClientAssertion,
 		httpClient:        httpClient,
 		initialLogin:      initialLogin,
 		realm:             realm,
 		userAgent:         userAgent,
 		redHatSSO:         redHatSSO,
 		additionalHeaders: additionalHeaders,
+		logRedactor:       newLogRedactor(options.RedactedLogFields),
+
+		accessTokenCommand: options.AccessTokenCommand,
+		staticAccessToken:  options.AccessToken != "",
+		readOnly:           options.ReadOnly,
+		backupDirectory:    options.BackupDirectory,
+	}
+
+	if options.CacheReads {
+		keycloakClient.readCache = newReadCache()
+	}
+
+	if options.HarRecordFile != "" {
+		keycloakClient.harRecorder = newHarRecorder(options.HarRecordFile)
+	}
+
+	if keycloakClient.staticAccessToken {
+		clientCredentials.setTokens(&ClientCredentials{AccessToken: options.AccessToken, TokenType: "Bearer"}, time.Now())
 	}
 
 	if keycloakClient.initialLogin {
@@ -112,124 +192,181 @@ func NewKeycloakClient(ctx context.Context, url, basePath, clientId, clientSecre
 }
 
 func (keycloakClient *KeycloakClient) login(ctx context.Context) error {
-	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	accessTokenData := keycloakClient.getAuthenticationFormData()
+	err := keycloakClient.ensureValidToken(ctx)
+	if err != nil {
+		return err
+	}
 
-	tflog.Debug(ctx, "Login request", map[string]interface{}{
-		"request": accessTokenData.Encode(),
-	})
+	info, err := keycloakClient.GetServerInfo(ctx)
+	if err != nil {
+		return err
+	}
 
-	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(accessTokenData.Encode()))
+	v, err := parseServerVersion(info.SystemInfo.ServerVersion)
 	if err != nil {
 		return err
 	}
 
-	for header, value := range keycloakClient.additionalHeaders {
-		accessTokenRequest.Header.Set(header, value)
+	// only Red Hat SSO 7 has versions of its own, the Red Hat build of Keycloak uses the upstream ones
+	if keycloakClient.redHatSSO && v.Segments()[0] == 7 {
+		keycloakVersion, ok := redHatSSO7VersionMap[v.Segments()[1]]
+		if !ok {
+			return fmt.Errorf("unsupported Red Hat SSO version %s", info.SystemInfo.ServerVersion)
+		}
+
+		v, err = version.NewVersion(keycloakVersion)
+		if err != nil {
+			return err
+		}
 	}
 
-	accessTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	keycloakClient.mutex.Lock()
+	keycloakClient.version = v
+	keycloakClient.serverInfo = info
+	keycloakClient.mutex.Unlock()
 
-	if keycloakClient.userAgent != "" {
-		accessTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
+	return nil
+}
+
+/*
+*
+Refreshes the access token with the refresh token, or logs in again if there is no usable refresh token.
+The caller must hold the lock on keycloakClient.mutex.
+*/
+func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
+	if !keycloakClient.clientCredentials.refreshTokenIsValid() {
+		tflog.Debug(ctx, "Refresh token is missing or expired, logging in again")
+
+		return keycloakClient.authenticate(ctx)
 	}
 
-	accessTokenResponse, err := keycloakClient.httpClient.Do(accessTokenRequest)
+	refreshFormData, err := keycloakClient.getRefreshFormData()
 	if err != nil {
 		return err
 	}
-	if accessTokenResponse.StatusCode != http.StatusOK {
-		return fmt.Errorf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status)
-	}
 
-	defer accessTokenResponse.Body.Close()
+	err = keycloakClient.requestToken(ctx, "Refresh", refreshFormData)
 
-	body, _ := ioutil.ReadAll(accessTokenResponse.Body)
+	// Handle 400 "User or client no longer has role permissions for client key" until I better understand why that happens in the first place
+	if ErrorIs400(err) {
+		tflog.Debug(ctx, "Unexpected 400, attempting to log in again")
 
-	tflog.Debug(ctx, "Login response", map[string]interface{}{
-		"response": string(body),
-	})
+		return keycloakClient.authenticate(ctx)
+	}
 
-	var clientCredentials ClientCredentials
-	err = json.Unmarshal(body, &clientCredentials)
-	if err != nil {
-		return err
+	return err
+}
+
+/*
+*
+Makes sure that a usable access token is available before a request is sent, logging in when there is no token yet
+and refreshing the token shortly before it expires. Concurrent callers wait for a single login or refresh.
+*/
+func (keycloakClient *KeycloakClient) ensureValidToken(ctx context.Context) error {
+	keycloakClient.mutex.RLock()
+	valid := keycloakClient.clientCredentials.accessTokenIsValid()
+	keycloakClient.mutex.RUnlock()
+
+	if valid {
+		return nil
 	}
 
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
+	keycloakClient.mutex.Lock()
+	defer keycloakClient.mutex.Unlock()
 
-	info, err := keycloakClient.GetServerInfo(ctx)
-	if err != nil {
-		return err
+	// another request may have already replaced the token while we were waiting for the lock
+	if keycloakClient.clientCredentials.accessTokenIsValid() {
+		return nil
 	}
 
-	serverVersion := info.SystemInfo.ServerVersion
-	if strings.Contains(serverVersion, ".GA") {
-		serverVersion = strings.ReplaceAll(info.SystemInfo.ServerVersion, ".GA", "")
+	if keycloakClient.clientCredentials.AccessToken == "" {
+		return keycloakClient.authenticate(ctx)
 	}
 
-	v, err := version.NewVersion(serverVersion)
-	if err != nil {
-		return err
+	tflog.Debug(ctx, "Access token is about to expire, attempting refresh")
+
+	return keycloakClient.refresh(ctx)
+}
+
+/*
+*
+Refreshes the access token after Keycloak rejected it, unless another request has already replaced it in the meantime.
+*/
+func (keycloakClient *KeycloakClient) refreshRejectedToken(ctx context.Context, rejectedAccessToken string) error {
+	keycloakClient.mutex.Lock()
+	defer keycloakClient.mutex.Unlock()
+
+	if keycloakClient.clientCredentials.AccessToken != rejectedAccessToken {
+		return nil
 	}
 
-	if keycloakClient.redHatSSO {
-		keycloakVersion, err := version.NewVersion(redHatSSO7VersionMap[v.Segments()[1]])
-		if err != nil {
-			return err
-		}
+	return keycloakClient.refresh(ctx)
+}
 
-		keycloakClient.version = keycloakVersion
-	} else {
-		keycloakClient.version = v
+/*
+*
+Logs in with the configured credentials. The caller must hold the lock on keycloakClient.mutex.
+*/
+func (keycloakClient *KeycloakClient) authenticate(ctx context.Context) error {
+	if len(keycloakClient.accessTokenCommand) != 0 {
+		return keycloakClient.runAccessTokenCommand(ctx)
 	}
 
-	return nil
+	if keycloakClient.staticAccessToken {
+		return fmt.Errorf("the access token given to the provider has expired or was rejected, and cannot be renewed without credentials")
+	}
+
+	authenticationFormData, err := keycloakClient.getAuthenticationFormData()
+	if err != nil {
+		return err
+	}
+
+	return keycloakClient.requestToken(ctx, "Login", authenticationFormData)
 }
 
-func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
-	refreshTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
-	refreshTokenData := keycloakClient.getAuthenticationFormData()
+/*
+*
+Requests a new token from the token endpoint and stores it. The caller must hold the lock on keycloakClient.mutex.
+*/
+func (keycloakClient *KeycloakClient) requestToken(ctx context.Context, description string, tokenFormData url.Values) error {
+	accessTokenUrl := fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm)
 
-	tflog.Debug(ctx, "Refresh request", map[string]interface{}{
-		"request": refreshTokenData.Encode(),
+	tflog.Debug(ctx, description+" request", map[string]interface{}{
+		"request": keycloakClient.getLogRedactor().redactForm(tokenFormData),
 	})
 
-	refreshTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, refreshTokenUrl, strings.NewReader(refreshTokenData.Encode()))
+	accessTokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, accessTokenUrl, strings.NewReader(tokenFormData.Encode()))
 	if err != nil {
 		return err
 	}
 
 	for header, value := range keycloakClient.additionalHeaders {
-		refreshTokenRequest.Header.Set(header, value)
+		accessTokenRequest.Header.Set(header, value)
 	}
 
-	refreshTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	accessTokenRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	if keycloakClient.userAgent != "" {
-		refreshTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
+		accessTokenRequest.Header.Set("User-Agent", keycloakClient.userAgent)
 	}
 
-	refreshTokenResponse, err := keycloakClient.httpClient.Do(refreshTokenRequest)
+	issuedAt := time.Now()
+
+	accessTokenResponse, err := keycloakClient.httpClient.Do(accessTokenRequest)
 	if err != nil {
 		return err
 	}
 
-	defer refreshTokenResponse.Body.Close()
+	defer accessTokenResponse.Body.Close()
 
-	body, _ := ioutil.ReadAll(refreshTokenResponse.Body)
+	body, _ := ioutil.ReadAll(accessTokenResponse.Body)
 
-	tflog.Debug(ctx, "Refresh response", map[string]interface{}{
-		"response": string(body),
+	tflog.Debug(ctx, description+" response", map[string]interface{}{
+		"response": keycloakClient.getLogRedactor().redactBody(body),
 	})
 
-	// Handle 401 "User or client no longer has role permissions for client key" until I better understand why that happens in the first place
-	if refreshTokenResponse.StatusCode == http.StatusBadRequest {
-		tflog.Debug(ctx, "Unexpected 400, attempting to log in again")
-
-		return keycloakClient.login(ctx)
+	if accessTokenResponse.StatusCode != http.StatusOK {
+		return newApiError(accessTokenResponse.StatusCode, fmt.Sprintf("error sending POST request to %s: %s", accessTokenUrl, accessTokenResponse.Status), body)
 	}
 
 	var clientCredentials ClientCredentials
@@ -238,14 +375,12 @@ func (keycloakClient *KeycloakClient) refresh(ctx context.Context) error {
 		return err
 	}
 
-	keycloakClient.clientCredentials.AccessToken = clientCredentials.AccessToken
-	keycloakClient.clientCredentials.RefreshToken = clientCredentials.RefreshToken
-	keycloakClient.clientCredentials.TokenType = clientCredentials.TokenType
+	keycloakClient.clientCredentials.setTokens(&clientCredentials, issuedAt)
 
 	return nil
 }
 
-func (keycloakClient *KeycloakClient) getAuthenticationFormData() url.Values {
+func (keycloakClient *KeycloakClient) getAuthenticationFormData() (url.Values, error) {
 	authenticationFormData := url.Values{}
 	authenticationFormData.Set("client_id", keycloakClient.clientCredentials.ClientId)
 	authenticationFormData.Set("grant_type", keycloakClient.clientCredentials.GrantType)
@@ -253,21 +388,60 @@ func (keycloakClient *KeycloakClient) getAuthenticationFormData() url.Values {
 	if keycloakClient.clientCredentials.GrantType == "password" {
 		authenticationFormData.Set("username", keycloakClient.clientCredentials.Username)
 		authenticationFormData.Set("password", keycloakClient.clientCredentials.Password)
+	}
+
+	err := keycloakClient.addClientAuthentication(authenticationFormData)
+	if err != nil {
+		return nil, err
+	}
+
+	return authenticationFormData, nil
+}
+
+func (keycloakClient *KeycloakClient) getRefreshFormData() (url.Values, error) {
+	refreshFormData := url.Values{}
+	refreshFormData.Set("client_id", keycloakClient.clientCredentials.ClientId)
+	refreshFormData.Set("grant_type", "refresh_token")
+	refreshFormData.Set("refresh_token", keycloakClient.clientCredentials.RefreshToken)
+
+	err := keycloakClient.addClientAuthentication(refreshFormData)
+	if err != nil {
+		return nil, err
+	}
+
+	return refreshFormData, nil
+}
 
-		if keycloakClient.clientCredentials.ClientSecret != "" {
-			authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+/*
+*
+Authenticates the client itself with either a signed JWT or the client secret. Clients using the X.509 certificate
+authenticator are authenticated by the TLS connection, so nothing needs to be added for them.
+*/
+func (keycloakClient *KeycloakClient) addClientAuthentication(formData url.Values) error {
+	if keycloakClient.clientAssertion != nil {
+		assertion, err := keycloakClient.clientAssertion.sign(keycloakClient.clientCredentials.ClientId, fmt.Sprintf(tokenUrl, keycloakClient.baseUrl, keycloakClient.realm))
+		if err != nil {
+			return fmt.Errorf("failed to sign client assertion: %v", err)
 		}
 
-	} else if keycloakClient.clientCredentials.GrantType == "client_credentials" {
-		authenticationFormData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
+		formData.Set("client_assertion_type", clientAssertionType)
+		formData.Set("client_assertion", assertion)
+	} else if keycloakClient.clientCredentials.ClientSecret != "" {
+		formData.Set("client_secret", keycloakClient.clientCredentials.ClientSecret)
 	}
 
-	return authenticationFormData
+	return nil
 }
 
-func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
+/*
+*
+Adds the authorization and content headers to the request, and returns the access token that was used
+*/
+func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) string {
+	keycloakClient.mutex.RLock()
 	tokenType := keycloakClient.clientCredentials.TokenType
 	accessToken := keycloakClient.clientCredentials.AccessToken
+	keycloakClient.mutex.RUnlock()
 
 	for header, value := range keycloakClient.additionalHeaders {
 		request.Header.Set(header, value)
@@ -283,6 +457,28 @@ func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
 	if request.Method == http.MethodPost || request.Method == http.MethodPut || request.Method == http.MethodDelete {
 		request.Header.Set("Content-type", "application/json")
 	}
+
+	return accessToken
+}
+
+/*
+*
+Sends an HTTP request and reads the response
+*/
+func (keycloakClient *KeycloakClient) doRequest(request *http.Request) (*http.Response, []byte, error) {
+	response, err := keycloakClient.httpClient.Do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer response.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return response, responseBody, nil
 }
 
 /*
@@ -290,14 +486,35 @@ func (keycloakClient *KeycloakClient) addRequestHeaders(request *http.Request) {
 Sends an HTTP request and refreshes credentials on 403 or 401 errors
 */
 func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *http.Request, body []byte) ([]byte, string, error) {
-	if !keycloakClient.initialLogin {
-		keycloakClient.initialLogin = true
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return nil, "", fmt.Errorf("error logging in: %s", err)
+	// tokens are requested without sendRequest, so logging in still works in read only mode
+	if keycloakClient.readOnly && request.Method != http.MethodGet {
+		return nil, "", fmt.Errorf("refusing to send %s request to %s: the provider is configured with read_only = true, so it can't change anything in Keycloak", request.Method, request.URL.Path)
+	}
+
+	var cacheGeneration uint64
+	if keycloakClient.readCache != nil {
+		if request.Method != http.MethodGet {
+			defer keycloakClient.readCache.invalidate(request.URL)
+		} else if isCacheableRead(request.URL) {
+			cachedBody, generation, ok := keycloakClient.readCache.get(request.URL)
+			if ok {
+				tflog.Debug(ctx, "Using cached response", map[string]interface{}{
+					"method": request.Method,
+					"path":   request.URL.Path,
+				})
+
+				return cachedBody, "", nil
+			}
+
+			cacheGeneration = generation
 		}
 	}
 
+	err := keycloakClient.ensureValidToken(ctx)
+	if err != nil {
+		return nil, "", fmt.Errorf("error logging in: %s", err)
+	}
+
 	requestMethod := request.Method
 	requestPath := request.URL.Path
 
@@ -308,14 +525,20 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 
 	if body != nil {
 		request.Body = ioutil.NopCloser(bytes.NewReader(body))
-		requestLogArgs["body"] = string(body)
+		requestLogArgs["body"] = keycloakClient.getLogRedactor().redactBody(body)
 	}
 
 	tflog.Debug(ctx, "Sending request", requestLogArgs)
 
-	keycloakClient.addRequestHeaders(request)
+	if requestMethod == http.MethodPost {
+		request = withNonIdempotentRequest(request)
+	}
 
-	response, err := keycloakClient.httpClient.Do(request)
+	accessToken := keycloakClient.addRequestHeaders(request)
+
+	started := time.Now()
+
+	response, responseBody, err := keycloakClient.doRequest(request)
 	if err != nil {
 		return nil, "", fmt.Errorf("error sending request: %v", err)
 	}
@@ -327,7 +550,7 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 			"status": response.Status,
 		})
 
-		err := keycloakClient.refresh(ctx)
+		err := keycloakClient.refreshRejectedToken(ctx, accessToken)
 		if err != nil {
 			return nil, "", fmt.Errorf("error refreshing credentials: %s", err)
 		}
@@ -337,40 +560,43 @@ func (keycloakClient *KeycloakClient) sendRequest(ctx context.Context, request *
 		if body != nil {
 			request.Body = ioutil.NopCloser(bytes.NewReader(body))
 		}
-		response, err = keycloakClient.httpClient.Do(request)
+		response, responseBody, err = keycloakClient.doRequest(request)
 		if err != nil {
 			return nil, "", fmt.Errorf("error sending request after refresh: %v", err)
 		}
 	}
 
-	defer response.Body.Close()
-
-	responseBody, err := ioutil.ReadAll(response.Body)
-	if err != nil {
-		return nil, "", err
-	}
-
 	responseLogArgs := map[string]interface{}{
 		"status": response.Status,
 	}
 
 	if len(responseBody) != 0 && request.URL.Path != "/auth/admin/serverinfo" {
-		responseLogArgs["body"] = string(responseBody)
+		responseLogArgs["body"] = keycloakClient.getLogRedactor().redactBody(responseBody)
 	}
 
 	tflog.Debug(ctx, "Received response", responseLogArgs)
 
+	if keycloakClient.harRecorder != nil {
+		err := keycloakClient.harRecorder.record(request, body, response, responseBody, started, keycloakClient.getLogRedactor())
+		if err != nil {
+			tflog.Warn(ctx, "Failed to record request to HAR file", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	if response.StatusCode >= 400 {
 		errorMessage := fmt.Sprintf("error sending %s request to %s: %s.", request.Method, request.URL.Path, response.Status)
 
 		if len(responseBody) != 0 {
-			errorMessage = fmt.Sprintf("%s Response body: %s", errorMessage, responseBody)
+			errorMessage = fmt.Sprintf("%s Response body: %s", errorMessage, keycloakClient.getLogRedactor().redactBody(responseBody))
 		}
 
-		return nil, "", &ApiError{
-			Code:    response.StatusCode,
-			Message: errorMessage,
-		}
+		return nil, "", newApiError(response.StatusCode, errorMessage, responseBody)
+	}
+
+	if keycloakClient.readCache != nil && request.Method == http.MethodGet && isCacheableRead(request.URL) {
+		keycloakClient.readCache.put(request.URL, responseBody, cacheGeneration)
 	}
 
 	return responseBody, response.Header.Get("Location"), nil
@@ -392,6 +618,47 @@ func (keycloakClient *KeycloakClient) getRoot(ctx context.Context, path string,
 	return json.Unmarshal(body, resource)
 }
 
+// getRootPaginated walks through a list endpoint that supports first/max paging. appendPage is called with the body of
+// every page and returns the number of entries it found, and paging stops at the first page that isn't full.
+func (keycloakClient *KeycloakClient) getRootPaginated(ctx context.Context, path string, params map[string]string, pageSize int, appendPage func(body []byte) (int, error)) error {
+	return getPages(params, pageSize, func(pageParams map[string]string) ([]byte, error) {
+		return keycloakClient.getRawRoot(ctx, path, pageParams)
+	}, appendPage)
+}
+
+// getPaginated is getRootPaginated for endpoints of the admin API
+func (keycloakClient *KeycloakClient) getPaginated(ctx context.Context, path string, params map[string]string, pageSize int, appendPage func(body []byte) (int, error)) error {
+	return getPages(params, pageSize, func(pageParams map[string]string) ([]byte, error) {
+		return keycloakClient.getRaw(ctx, path, pageParams)
+	}, appendPage)
+}
+
+func getPages(params map[string]string, pageSize int, getPage func(params map[string]string) ([]byte, error), appendPage func(body []byte) (int, error)) error {
+	pageParams := map[string]string{}
+	for k, v := range params {
+		pageParams[k] = v
+	}
+
+	for first := 0; ; first += pageSize {
+		pageParams["first"] = strconv.Itoa(first)
+		pageParams["max"] = strconv.Itoa(pageSize)
+
+		body, err := getPage(pageParams)
+		if err != nil {
+			return err
+		}
+
+		count, err := appendPage(body)
+		if err != nil {
+			return err
+		}
+
+		if count < pageSize {
+			return nil
+		}
+	}
+}
+
 func (keycloakClient *KeycloakClient) getRaw(ctx context.Context, path string, params map[string]string) ([]byte, error) {
 	resourceUrl := keycloakClient.baseUrl + apiUrl + path
 
@@ -575,7 +842,7 @@ func (keycloakClient *KeycloakClient) marshal(body interface{}) ([]byte, error)
 	return json.Marshal(body)
 }
 
-func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string) (*http.Client, error) {
+func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert, tlsClientCertificate, tlsClientKey string, retryPolicy RetryPolicy, connectionPool ConnectionPool) (*http.Client, error) {
 	cookieJar, err := cookiejar.New(&cookiejar.Options{
 		PublicSuffixList: publicsuffix.List,
 	})
@@ -588,20 +855,30 @@ func newHttpClient(tlsInsecureSkipVerify bool, clientTimeout int, caCert string)
 		Proxy:           http.ProxyFromEnvironment,
 	}
 
+	connectionPool.configureTransport(transport)
+
 	if caCert != "" {
 		caCertPool := x509.NewCertPool()
 		caCertPool.AppendCertsFromPEM([]byte(caCert))
 		transport.TLSClientConfig.RootCAs = caCertPool
 	}
 
-	retryClient := retryablehttp.NewClient()
-	retryClient.RetryMax = 1
-	retryClient.RetryWaitMin = time.Second * 1
-	retryClient.RetryWaitMax = time.Second * 3
+	if tlsClientCertificate != "" || tlsClientKey != "" {
+		clientCertificate, err := tls.X509KeyPair([]byte(tlsClientCertificate), []byte(tlsClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %v", err)
+		}
+
+		transport.TLSClientConfig.Certificates = []tls.Certificate{clientCertificate}
+	}
+
+	retryClient := retryPolicy.newRetryClient()
+	retryClient.HTTPClient = &http.Client{
+		Timeout:   time.Second * time.Duration(clientTimeout),
+		Transport: connectionPool.wrapTransport(transport),
+	}
 
 	httpClient := retryClient.StandardClient()
-	httpClient.Timeout = time.Second * time.Duration(clientTimeout)
-	httpClient.Transport = transport
 	httpClient.Jar = cookieJar
 
 	return httpClient, nil
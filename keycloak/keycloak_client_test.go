@@ -50,7 +50,7 @@ func TestAccKeycloakApiClientRefresh(t *testing.T) {
 
 	keycloakClient, err := NewKeycloakClient(ctx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), os.Getenv("KEYCLOAK_USER"), os.Getenv("KEYCLOAK_PASSWORD"), true, clientTimeout, "", false, "", false, map[string]string{
 		"foo": "bar",
-	})
+	}, KeycloakClientOptions{})
 	if err != nil {
 		t.Fatalf("%s", err)
 	}
@@ -0,0 +1,1064 @@
+// Package keycloaktest provides an in-memory fake of the Keycloak admin API, so the keycloak and provider packages can be
+// tested without running Keycloak.
+//
+// The fake implements the token endpoint, serverinfo, partial exports, and basic CRUD for realms, clients, client
+// scopes, protocol mappers, roles, groups, users, components, identity providers, authentication flows, webhooks and
+// organizations, with their members, roles, domains, identity providers and invitations. Objects are stored as they are
+// sent, so anything the fake doesn't know about round trips unchanged, but none of Keycloak's defaults or validation is
+// applied.
+package keycloaktest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Server is a fake Keycloak. The credentials and version can be changed before the first request is sent.
+type Server struct {
+	*httptest.Server
+
+	ClientId     string
+	ClientSecret string
+	Username     string
+	Password     string
+	Version      string
+
+	mutex    sync.Mutex
+	objects  map[string]map[string]interface{}
+	order    []string
+	tokens   map[string]bool
+	nextId   int
+	validate Validator
+}
+
+// Validator checks the body of a request that creates or updates an object. When it returns a field, the request is
+// rejected with a 400 naming the field, like Keycloak's validation does.
+type Validator func(method, path string, body map[string]interface{}) (field, errorMessage string)
+
+// NewServer starts a fake Keycloak with a master realm, which is stopped when the test finishes.
+func NewServer(t testing.TB) *Server {
+	server := Start()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Start starts a fake Keycloak with a master realm outside of a test, such as in TestMain. It has to be closed by the
+// caller.
+func Start() *Server {
+	server := &Server{
+		ClientId:     "terraform",
+		ClientSecret: "secret",
+		Username:     "keycloak",
+		Password:     "password",
+		Version:      "26.0.0",
+		objects:      map[string]map[string]interface{}{},
+		tokens:       map[string]bool{},
+	}
+
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	server.store("realms/master", map[string]interface{}{
+		"id":      "master",
+		"realm":   "master",
+		"enabled": true,
+	})
+
+	return server
+}
+
+// SetValidator validates the requests that create or update objects with validate, until it is replaced. Passing nil
+// accepts every request again.
+func (server *Server) SetValidator(validate Validator) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.validate = validate
+}
+
+type fakeError struct {
+	status int
+	body   map[string]interface{}
+}
+
+func notFound(message string) *fakeError {
+	return &fakeError{http.StatusNotFound, map[string]interface{}{"error": message}}
+}
+
+func conflict(message string) *fakeError {
+	return &fakeError{http.StatusConflict, map[string]interface{}{"errorMessage": message}}
+}
+
+func badRequest(message string) *fakeError {
+	return &fakeError{http.StatusBadRequest, map[string]interface{}{"errorMessage": message}}
+}
+
+var methodNotAllowed = &fakeError{http.StatusMethodNotAllowed, map[string]interface{}{"error": "RESTEASY003650: No resource method found"}}
+
+func (server *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/auth")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(segments) == 5 && segments[0] == "realms" && strings.Join(segments[2:], "/") == "protocol/openid-connect/token" {
+		server.serveToken(w, r, segments[1])
+		return
+	}
+
+	if !server.isAuthorized(r) {
+		writeJson(w, http.StatusUnauthorized, map[string]interface{}{"error": "HTTP 401 Unauthorized"})
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		// partial exports are the only requests that are sent without a body
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil && !(err == io.EOF && segments[len(segments)-1] == "partial-export") {
+			writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": "unable to read contents from stream"})
+			return
+		}
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if server.validate != nil && body != nil {
+		if field, errorMessage := server.validate(r.Method, path, body); field != "" {
+			writeJson(w, http.StatusBadRequest, map[string]interface{}{"field": field, "errorMessage": errorMessage})
+			return
+		}
+	}
+
+	status, response, location, fakeErr := server.route(r.Method, segments, r.URL.Query(), body)
+	if fakeErr != nil {
+		writeJson(w, fakeErr.status, fakeErr.body)
+		return
+	}
+
+	if location != "" {
+		w.Header().Set("Location", server.URL+strings.TrimSuffix(r.URL.Path, "/")+"/"+location)
+	}
+
+	writeJson(w, status, response)
+}
+
+func writeJson(w http.ResponseWriter, status int, body interface{}) {
+	if body == nil {
+		w.WriteHeader(status)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (server *Server) serveToken(w http.ResponseWriter, r *http.Request, realm string) {
+	if err := r.ParseForm(); err != nil {
+		writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_request"})
+		return
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	if _, ok := server.objects["realms/"+realm]; !ok {
+		writeJson(w, http.StatusNotFound, map[string]interface{}{"error": "Realm does not exist"})
+		return
+	}
+
+	if r.PostForm.Get("client_id") != server.ClientId {
+		writeJson(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client", "error_description": "Invalid client or Invalid client credentials"})
+		return
+	}
+
+	switch r.PostForm.Get("grant_type") {
+	case "client_credentials":
+		if r.PostForm.Get("client_secret") != server.ClientSecret {
+			writeJson(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_client", "error_description": "Invalid client or Invalid client credentials"})
+			return
+		}
+	case "password":
+		if r.PostForm.Get("username") != server.Username || r.PostForm.Get("password") != server.Password {
+			writeJson(w, http.StatusUnauthorized, map[string]interface{}{"error": "invalid_grant", "error_description": "Invalid user credentials"})
+			return
+		}
+	case "refresh_token":
+		if !server.tokens[r.PostForm.Get("refresh_token")] {
+			writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": "invalid_grant", "error_description": "Invalid refresh token"})
+			return
+		}
+	default:
+		writeJson(w, http.StatusBadRequest, map[string]interface{}{"error": "unsupported_grant_type"})
+		return
+	}
+
+	server.nextId++
+	accessToken := fmt.Sprintf("fake-access-token-%d", server.nextId)
+	refreshToken := fmt.Sprintf("fake-refresh-token-%d", server.nextId)
+	server.tokens[accessToken] = true
+	server.tokens[refreshToken] = true
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"access_token":       accessToken,
+		"refresh_token":      refreshToken,
+		"token_type":         "Bearer",
+		"expires_in":         300,
+		"refresh_expires_in": 1800,
+	})
+}
+
+func (server *Server) isAuthorized(r *http.Request) bool {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return false
+	}
+
+	return server.tokens[strings.TrimPrefix(authorization, "Bearer ")]
+}
+
+// route returns the status, body and, for created objects, the last segment of the Location header of a response
+func (server *Server) route(method string, segments []string, query url.Values, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	if len(segments) == 2 && segments[0] == "admin" && segments[1] == "serverinfo" {
+		return http.StatusOK, server.serverInfo(), "", nil
+	}
+
+	// organizations are served by the phasetwo extension, outside of the admin API
+	if len(segments) >= 3 && segments[0] == "realms" && segments[2] == "orgs" {
+		if _, ok := server.objects["realms/"+segments[1]]; !ok {
+			return 0, nil, "", notFound("Realm not found.")
+		}
+
+		return server.routeOrganizations(method, "realms/"+segments[1], segments[3:], query, body)
+	}
+
+	// webhooks are served by the phasetwo extension too
+	if len(segments) >= 3 && segments[0] == "realms" && segments[2] == "webhooks" {
+		if _, ok := server.objects["realms/"+segments[1]]; !ok {
+			return 0, nil, "", notFound("Realm not found.")
+		}
+
+		return server.routeWebhooks(method, "realms/"+segments[1]+"/webhooks", segments[3:], query, body)
+	}
+
+	if len(segments) < 2 || segments[0] != "admin" || segments[1] != "realms" {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	segments = segments[2:]
+
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, server.list("realms/", nil), "", nil
+		case http.MethodPost:
+			return server.createRealm(body)
+		}
+
+		return 0, nil, "", methodNotAllowed
+	}
+
+	realmPath := "realms/" + segments[0]
+	if _, ok := server.objects[realmPath]; !ok {
+		return 0, nil, "", notFound("Realm not found.")
+	}
+
+	if len(segments) == 1 {
+		return server.routeObject(method, realmPath, body)
+	}
+
+	switch {
+	case segments[1] == "partial-export" && len(segments) == 2 && method == http.MethodPost:
+		// only clients are exported, without masking their secrets
+		realm := copyObject(server.objects[realmPath])
+		if query.Get("exportClients") == "true" {
+			realm["clients"] = server.list(realmPath+"/clients/", nil)
+		}
+
+		return http.StatusOK, realm, "", nil
+	case segments[1] == "roles":
+		return server.routeRoles(method, realmPath, server.objects[realmPath]["id"].(string), false, segments[2:], body)
+	case segments[1] == "roles-by-id" && len(segments) == 3:
+		return server.routeObject(method, realmPath+"/roles/"+segments[2], body)
+	case segments[1] == "clients" && len(segments) >= 4 && segments[3] == "roles":
+		if _, ok := server.objects[realmPath+"/clients/"+segments[2]]; !ok {
+			return 0, nil, "", notFound("Could not find client")
+		}
+
+		return server.routeRoles(method, realmPath, segments[2], true, segments[4:], body)
+	case segments[1] == "groups":
+		return server.routeGroups(method, realmPath, segments[2:], query, body)
+	case segments[1] == "group-by-path" && len(segments) > 2 && method == http.MethodGet:
+		path := "/" + strings.Join(segments[2:], "/")
+		for _, group := range server.list(realmPath+"/groups/", nil) {
+			if server.groupPath(realmPath+"/groups/", group) == path {
+				return server.routeGroups(method, realmPath, []string{group["id"].(string)}, query, body)
+			}
+		}
+
+		return 0, nil, "", notFound("Group path does not exist")
+	case (segments[1] == "clients" || segments[1] == "client-scopes") && len(segments) >= 5 && segments[3] == "protocol-mappers" && segments[4] == "models":
+		parentPath := realmPath + "/" + segments[1] + "/" + segments[2]
+		if _, ok := server.objects[parentPath]; !ok {
+			return 0, nil, "", notFound("Could not find " + strings.TrimSuffix(segments[1], "s"))
+		}
+
+		return server.routeCollection(method, parentPath+"/protocol-mappers/models", segments[5:], query, body, "name")
+	case segments[1] == "clients" && len(segments) == 4 && segments[3] == "client-secret" && method == http.MethodGet:
+		client, ok := server.objects[realmPath+"/clients/"+segments[2]]
+		if !ok {
+			return 0, nil, "", notFound("Could not find client")
+		}
+
+		return http.StatusOK, map[string]interface{}{"type": "secret", "value": client["secret"]}, "", nil
+	case segments[1] == "clients":
+		return server.routeCollection(method, realmPath+"/clients", segments[2:], query, body, "clientId")
+	case segments[1] == "client-scopes":
+		return server.routeCollection(method, realmPath+"/client-scopes", segments[2:], query, body, "name")
+	case segments[1] == "identity-provider" && len(segments) >= 5 && segments[2] == "instances" && segments[4] == "mappers":
+		parentPath := realmPath + "/identity-provider/instances/" + segments[3]
+		if _, ok := server.objects[parentPath]; !ok {
+			return 0, nil, "", notFound("Could not find identity provider")
+		}
+
+		return server.routeCollection(method, parentPath+"/mappers", segments[5:], query, body, "name")
+	case segments[1] == "identity-provider" && len(segments) >= 3 && segments[2] == "instances":
+		return server.routeKeyedCollection(method, realmPath+"/identity-provider/instances", segments[3:], body, "alias")
+	case segments[1] == "authentication" && len(segments) == 5 && segments[2] == "flows" && segments[4] == "executions" && method == http.MethodGet:
+		// flows are served without any executions
+		for _, flow := range server.list(realmPath+"/authentication/flows/", nil) {
+			if flow["alias"] == segments[3] {
+				return http.StatusOK, []interface{}{}, "", nil
+			}
+		}
+
+		return 0, nil, "", notFound("Could not find flow")
+	case segments[1] == "authentication" && len(segments) >= 3 && segments[2] == "flows":
+		return server.routeCollection(method, realmPath+"/authentication/flows", segments[3:], query, body, "alias")
+	case segments[1] == "users":
+		if body != nil {
+			// usernames are always stored in lower case, and credentials are never returned
+			if username, ok := body["username"].(string); ok {
+				body["username"] = strings.ToLower(username)
+			}
+			delete(body, "credentials")
+		}
+
+		return server.routeCollection(method, realmPath+"/users", segments[2:], query, body, "username")
+	case segments[1] == "components":
+		// components are searched by the parent and type query parameters, which are stored as parentId and providerType
+		componentQuery := url.Values{}
+		for key, values := range query {
+			switch key {
+			case "parent":
+				key = "parentId"
+			case "type":
+				key = "providerType"
+			}
+			componentQuery[key] = values
+		}
+		componentQuery.Set("exact", "true")
+
+		return server.routeCollection(method, realmPath+"/components", segments[2:], componentQuery, body, "")
+	}
+
+	return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+}
+
+func (server *Server) serverInfo() map[string]interface{} {
+	return map[string]interface{}{
+		"systemInfo": map[string]interface{}{
+			"version": server.Version,
+		},
+		"features":       []interface{}{},
+		"componentTypes": map[string]interface{}{},
+		"providers":      map[string]interface{}{},
+		"themes":         map[string]interface{}{},
+	}
+}
+
+func (server *Server) createRealm(body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	name, _ := body["realm"].(string)
+	if name == "" {
+		return 0, nil, "", badRequest("Realm name cannot be empty")
+	}
+
+	if _, ok := server.objects["realms/"+name]; ok {
+		return 0, nil, "", conflict(fmt.Sprintf("Realm %s already exists", name))
+	}
+
+	if _, ok := body["id"]; !ok {
+		body["id"] = name
+	}
+
+	server.store("realms/"+name, body)
+
+	return http.StatusCreated, nil, name, nil
+}
+
+// routeOrganizations serves organizations, with their members, roles, domains, identity providers and invitations
+func (server *Server) routeOrganizations(method, realmPath string, segments []string, query url.Values, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	if len(segments) <= 1 {
+		return server.routeCollection(method, realmPath+"/orgs", segments, query, body, "name")
+	}
+
+	orgPath := realmPath + "/orgs/" + segments[0]
+	organization, ok := server.objects[orgPath]
+	if !ok {
+		return 0, nil, "", notFound("Could not find organization")
+	}
+
+	switch {
+	case segments[1] == "members":
+		return server.routeUserLinks(method, realmPath, orgPath+"/members", segments[2:], query)
+	case segments[1] == "roles" && len(segments) >= 4 && segments[3] == "users":
+		if _, ok := server.objects[orgPath+"/roles/"+segments[2]]; !ok {
+			return 0, nil, "", notFound("Could not find role")
+		}
+
+		return server.routeUserLinks(method, realmPath, orgPath+"/roles/"+segments[2]+"/users", segments[4:], query)
+	case segments[1] == "roles":
+		return server.routeKeyedCollection(method, orgPath+"/roles", segments[2:], body, "name")
+	case segments[1] == "domains" && method == http.MethodGet:
+		// domains are stored on the organization, and are never verified since the fake doesn't look up DNS records
+		domains := []map[string]interface{}{}
+		names, _ := organization["domains"].([]interface{})
+		for _, name := range names {
+			if len(segments) == 2 || segments[2] == name {
+				domains = append(domains, map[string]interface{}{
+					"domain_name":  name,
+					"verified":     false,
+					"record_key":   "_org-domain-verification." + name.(string),
+					"record_value": segments[0],
+					"type":         "TXT",
+				})
+			}
+		}
+
+		if len(segments) == 2 {
+			return http.StatusOK, domains, "", nil
+		}
+		if len(domains) == 0 {
+			return 0, nil, "", notFound("Could not find domain")
+		}
+
+		return http.StatusOK, domains[0], "", nil
+	case segments[1] == "domains" && len(segments) == 4 && segments[3] == "verify" && method == http.MethodPost:
+		return http.StatusOK, map[string]interface{}{"verified": false}, "", nil
+	case segments[1] == "idps":
+		return server.routeOrganizationIdentityProviders(method, realmPath, segments[0], segments[2:], body)
+	case segments[1] == "invitations":
+		return server.routeCollection(method, orgPath+"/invitations", segments[2:], query, body, "email")
+	}
+
+	return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+}
+
+// routeUserLinks serves a list of users, such as the members of an organization, that users are added to with a PUT
+// and removed from with a DELETE of their id
+func (server *Server) routeUserLinks(method, realmPath, linksPath string, segments []string, query url.Values) (int, interface{}, string, *fakeError) {
+	if len(segments) == 0 {
+		if method != http.MethodGet {
+			return 0, nil, "", methodNotAllowed
+		}
+
+		users := []map[string]interface{}{}
+		for _, link := range server.list(linksPath+"/", nil) {
+			if user, ok := server.objects[realmPath+"/users/"+link["id"].(string)]; ok {
+				users = append(users, copyObject(user))
+			}
+		}
+
+		return http.StatusOK, paginate(users, query), "", nil
+	}
+
+	if len(segments) > 1 {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	linkPath := linksPath + "/" + segments[0]
+	_, linked := server.objects[linkPath]
+
+	switch method {
+	case http.MethodGet:
+		if !linked {
+			return 0, nil, "", notFound("Could not find user")
+		}
+
+		return http.StatusNoContent, nil, "", nil
+	case http.MethodPut:
+		if _, ok := server.objects[realmPath+"/users/"+segments[0]]; !ok {
+			return 0, nil, "", notFound("Could not find user")
+		}
+
+		server.store(linkPath, map[string]interface{}{"id": segments[0]})
+
+		return http.StatusCreated, nil, "", nil
+	case http.MethodDelete:
+		if !linked {
+			return 0, nil, "", notFound("Could not find user")
+		}
+
+		server.remove(linkPath)
+
+		return http.StatusNoContent, nil, "", nil
+	}
+
+	return 0, nil, "", methodNotAllowed
+}
+
+// routeOrganizationIdentityProviders links identity providers to an organization the way the phasetwo extension does,
+// by storing the id of the organization in their config
+func (server *Server) routeOrganizationIdentityProviders(method, realmPath, orgId string, segments []string, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	instancesPath := realmPath + "/identity-provider/instances/"
+	linked := func(identityProvider map[string]interface{}) bool {
+		config, _ := identityProvider["config"].(map[string]interface{})
+		return config != nil && config[organizationConfigKey] == orgId
+	}
+
+	switch {
+	case len(segments) == 0 && method == http.MethodGet:
+		return http.StatusOK, server.list(instancesPath, linked), "", nil
+	case len(segments) == 1 && segments[0] == "link" && method == http.MethodPost:
+		alias, _ := body["alias"].(string)
+		identityProvider, ok := server.objects[instancesPath+alias]
+		if !ok {
+			return 0, nil, "", notFound("Could not find identity provider")
+		}
+
+		config, _ := identityProvider["config"].(map[string]interface{})
+		if config == nil {
+			config = map[string]interface{}{}
+			identityProvider["config"] = config
+		}
+		config[organizationConfigKey] = orgId
+		if syncMode, _ := body["sync_mode"].(string); syncMode != "" {
+			config["syncMode"] = syncMode
+		}
+		if postBrokerFlow, _ := body["post_broker_flow"].(string); postBrokerFlow != "" {
+			identityProvider["postBrokerLoginFlowAlias"] = postBrokerFlow
+		}
+
+		return http.StatusCreated, nil, alias, nil
+	case len(segments) == 2 && segments[1] == "unlink" && method == http.MethodPost:
+		identityProvider, ok := server.objects[instancesPath+segments[0]]
+		if !ok || !linked(identityProvider) {
+			return 0, nil, "", notFound("Could not find identity provider")
+		}
+
+		delete(identityProvider["config"].(map[string]interface{}), organizationConfigKey)
+
+		return http.StatusNoContent, nil, "", nil
+	}
+
+	return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+}
+
+const organizationConfigKey = "home.idp.discovery.org"
+
+// routeWebhooks serves webhooks, which never return their secret. Test events are sent to the url of the webhook, and
+// are listed with its sends, newest first.
+func (server *Server) routeWebhooks(method, webhooksPath string, segments []string, query url.Values, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	switch {
+	case len(segments) <= 1:
+		status, response, location, fakeErr := server.routeCollection(method, webhooksPath, segments, query, body, "")
+		switch response := response.(type) {
+		case map[string]interface{}:
+			delete(response, "secret")
+		case []map[string]interface{}:
+			for _, webhook := range response {
+				delete(webhook, "secret")
+			}
+		}
+
+		return status, response, location, fakeErr
+	case len(segments) == 2 && segments[1] == "test" && method == http.MethodPost:
+		webhook, ok := server.objects[webhooksPath+"/"+segments[0]]
+		if !ok {
+			return 0, nil, "", notFound("Could not find webhook")
+		}
+
+		server.nextId++
+		eventId := fmt.Sprintf("test-event-%d", server.nextId)
+
+		event, _ := json.Marshal(map[string]interface{}{"id": eventId, "type": "test"})
+		webhookUrl, _ := webhook["url"].(string)
+		response, err := http.Post(webhookUrl, "application/json", bytes.NewReader(event))
+		if err != nil {
+			return 0, nil, "", &fakeError{http.StatusInternalServerError, map[string]interface{}{"error": err.Error()}}
+		}
+		response.Body.Close()
+
+		server.create(webhooksPath+"/"+segments[0]+"/sends/", map[string]interface{}{
+			"event_type": "test",
+			"event_id":   eventId,
+			"status":     response.StatusCode,
+			"retries":    0,
+			"sent_at":    time.Now().UnixMilli(),
+		})
+
+		return http.StatusOK, map[string]interface{}{"status": response.StatusCode}, "", nil
+	case len(segments) == 2 && segments[1] == "sends" && method == http.MethodGet:
+		if _, ok := server.objects[webhooksPath+"/"+segments[0]]; !ok {
+			return 0, nil, "", notFound("Could not find webhook")
+		}
+
+		sends := server.list(webhooksPath+"/"+segments[0]+"/sends/", nil)
+		for i, j := 0, len(sends)-1; i < j; i, j = i+1, j-1 {
+			sends[i], sends[j] = sends[j], sends[i]
+		}
+
+		return http.StatusOK, paginate(sends, query), "", nil
+	}
+
+	return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+}
+
+// routeCollection serves a list of objects identified by their id. uniqueField, when set, can't be shared by two objects.
+func (server *Server) routeCollection(method, collectionPath string, segments []string, query url.Values, body map[string]interface{}, uniqueField string) (int, interface{}, string, *fakeError) {
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, paginate(filter(server.list(collectionPath+"/", nil), query), query), "", nil
+		case http.MethodPost:
+			if fakeErr := server.checkUnique(collectionPath+"/", uniqueField, body, nil); fakeErr != nil {
+				return 0, nil, "", fakeErr
+			}
+
+			id := server.create(collectionPath+"/", body)
+
+			return http.StatusCreated, nil, id, nil
+		}
+
+		return 0, nil, "", methodNotAllowed
+	}
+
+	if len(segments) > 1 {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	if method == http.MethodPut {
+		if fakeErr := server.checkUnique(collectionPath+"/", uniqueField, body, func(object map[string]interface{}) bool {
+			return object["id"] == segments[0]
+		}); fakeErr != nil {
+			return 0, nil, "", fakeErr
+		}
+	}
+
+	return server.routeObject(method, collectionPath+"/"+segments[0], body)
+}
+
+// routeKeyedCollection serves a list of objects that are identified by one of their fields instead of an id, like the
+// alias of an identity provider.
+func (server *Server) routeKeyedCollection(method, collectionPath string, segments []string, body map[string]interface{}, keyField string) (int, interface{}, string, *fakeError) {
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, server.list(collectionPath+"/", nil), "", nil
+		case http.MethodPost:
+			key, _ := body[keyField].(string)
+			if key == "" {
+				return 0, nil, "", badRequest(fmt.Sprintf("%s cannot be empty", keyField))
+			}
+
+			if _, ok := server.objects[collectionPath+"/"+key]; ok {
+				return 0, nil, "", conflict(fmt.Sprintf("Object with same %s exists", keyField))
+			}
+
+			server.store(collectionPath+"/"+key, body)
+
+			return http.StatusCreated, nil, key, nil
+		}
+
+		return 0, nil, "", methodNotAllowed
+	}
+
+	if len(segments) > 1 {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	return server.routeObject(method, collectionPath+"/"+segments[0], body)
+}
+
+// Realm roles and client roles are both stored under the realm, so they can be looked up by id
+func (server *Server) routeRoles(method, realmPath, containerId string, clientRole bool, segments []string, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	inContainer := func(role map[string]interface{}) bool {
+		return role["containerId"] == containerId
+	}
+
+	if len(segments) == 0 {
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, server.list(realmPath+"/roles/", inContainer), "", nil
+		case http.MethodPost:
+			if fakeErr := server.checkUnique(realmPath+"/roles/", "name", body, func(role map[string]interface{}) bool {
+				return !inContainer(role)
+			}); fakeErr != nil {
+				return 0, nil, "", fakeErr
+			}
+
+			body["containerId"] = containerId
+			body["clientRole"] = clientRole
+			server.create(realmPath+"/roles/", body)
+
+			return http.StatusCreated, nil, body["name"].(string), nil
+		}
+
+		return 0, nil, "", methodNotAllowed
+	}
+
+	if len(segments) > 1 {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	for _, role := range server.list(realmPath+"/roles/", inContainer) {
+		if role["name"] == segments[0] {
+			return server.routeObject(method, realmPath+"/roles/"+role["id"].(string), body)
+		}
+	}
+
+	return 0, nil, "", notFound("Could not find role")
+}
+
+func (server *Server) routeGroups(method, realmPath string, segments []string, query url.Values, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	groupsPath := realmPath + "/groups/"
+
+	if len(segments) == 0 || (len(segments) == 2 && segments[1] == "children") {
+		parentId := ""
+		if len(segments) == 2 {
+			parentId = segments[0]
+			if _, ok := server.objects[groupsPath+parentId]; !ok {
+				return 0, nil, "", notFound("Could not find group by id")
+			}
+		}
+
+		isChild := func(group map[string]interface{}) bool {
+			id, _ := group["parentId"].(string)
+			return id == parentId
+		}
+
+		switch method {
+		case http.MethodGet:
+			groups := server.list(groupsPath, isChild)
+			for _, group := range groups {
+				server.addSubGroups(groupsPath, group)
+			}
+
+			// like Keycloak, searching returns the groups that have a matching group in their hierarchy
+			if search := query.Get("search"); search != "" {
+				groups = filterBy(groups, func(group map[string]interface{}) bool {
+					return groupMatches(group, strings.ToLower(search))
+				})
+			}
+
+			for _, group := range groups {
+				server.trimSubGroups(group)
+			}
+
+			return http.StatusOK, paginate(groups, query), "", nil
+		case http.MethodPost:
+			if fakeErr := server.checkUnique(groupsPath, "name", body, func(group map[string]interface{}) bool {
+				return !isChild(group)
+			}); fakeErr != nil {
+				return 0, nil, "", fakeErr
+			}
+
+			if parentId != "" {
+				body["parentId"] = parentId
+			}
+
+			return http.StatusCreated, nil, server.create(groupsPath, body), nil
+		}
+
+		return 0, nil, "", methodNotAllowed
+	}
+
+	if len(segments) > 1 {
+		return 0, nil, "", notFound("RESTEASY003210: Could not find resource for full path")
+	}
+
+	status, response, location, fakeErr := server.routeObject(method, groupsPath+segments[0], body)
+	if group, ok := response.(map[string]interface{}); ok {
+		server.addSubGroups(groupsPath, group)
+		server.trimSubGroups(group)
+	}
+
+	return status, response, location, fakeErr
+}
+
+func (server *Server) addSubGroups(groupsPath string, group map[string]interface{}) {
+	group["path"] = server.groupPath(groupsPath, group)
+
+	subGroups := server.list(groupsPath, func(subGroup map[string]interface{}) bool {
+		return subGroup["parentId"] == group["id"]
+	})
+	for _, subGroup := range subGroups {
+		server.addSubGroups(groupsPath, subGroup)
+	}
+
+	group["subGroups"] = subGroups
+	group["subGroupCount"] = len(subGroups)
+}
+
+// Since Keycloak 23, groups are returned without their subgroups, which have to be fetched from the children endpoint
+func (server *Server) trimSubGroups(group map[string]interface{}) {
+	major, _ := strconv.Atoi(strings.Split(server.Version, ".")[0])
+	if major >= 23 {
+		delete(group, "subGroups")
+	}
+}
+
+func groupMatches(group map[string]interface{}, search string) bool {
+	if strings.Contains(strings.ToLower(fmt.Sprint(group["name"])), search) {
+		return true
+	}
+
+	subGroups, _ := group["subGroups"].([]map[string]interface{})
+	for _, subGroup := range subGroups {
+		if groupMatches(subGroup, search) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (server *Server) groupPath(groupsPath string, group map[string]interface{}) string {
+	path := "/" + fmt.Sprint(group["name"])
+	if parentId, ok := group["parentId"].(string); ok {
+		if parent, ok := server.objects[groupsPath+parentId]; ok {
+			return server.groupPath(groupsPath, parent) + path
+		}
+	}
+
+	return path
+}
+
+func (server *Server) routeObject(method, path string, body map[string]interface{}) (int, interface{}, string, *fakeError) {
+	object, ok := server.objects[path]
+	if !ok {
+		segments := strings.Split(path, "/")
+		return 0, nil, "", notFound("Could not find " + strings.TrimSuffix(segments[len(segments)-2], "s"))
+	}
+
+	switch method {
+	case http.MethodGet:
+		return http.StatusOK, copyObject(object), "", nil
+	case http.MethodPut:
+		for key, value := range body {
+			if key != "id" && key != "parentId" && key != "containerId" {
+				object[key] = value
+			}
+		}
+
+		return http.StatusNoContent, nil, "", nil
+	case http.MethodDelete:
+		server.remove(path)
+
+		return http.StatusNoContent, nil, "", nil
+	}
+
+	return 0, nil, "", methodNotAllowed
+}
+
+func (server *Server) checkUnique(collectionPath, uniqueField string, body map[string]interface{}, ignore func(map[string]interface{}) bool) *fakeError {
+	if uniqueField == "" {
+		return nil
+	}
+
+	value, ok := body[uniqueField]
+	if !ok {
+		return nil
+	}
+
+	for _, object := range server.list(collectionPath, nil) {
+		if ignore != nil && ignore(object) {
+			continue
+		}
+
+		if object[uniqueField] == value {
+			return conflict(fmt.Sprintf("Object with same %s exists", uniqueField))
+		}
+	}
+
+	return nil
+}
+
+func (server *Server) store(path string, object map[string]interface{}) {
+	if _, ok := server.objects[path]; !ok {
+		server.order = append(server.order, path)
+	}
+
+	server.objects[path] = object
+}
+
+func (server *Server) create(collectionPath string, object map[string]interface{}) string {
+	id, ok := object["id"].(string)
+	if !ok || id == "" {
+		server.nextId++
+		id = fmt.Sprintf("00000000-0000-4000-8000-%012d", server.nextId)
+		object["id"] = id
+	}
+
+	server.store(collectionPath+id, object)
+
+	return id
+}
+
+// remove deletes an object, along with every object stored under it and the objects that belong to it, such as the
+// roles of a client or the subgroups of a group.
+func (server *Server) remove(path string) {
+	removed := map[string]bool{path: true}
+	removedIds := map[string]bool{path[strings.LastIndex(path, "/")+1:]: true}
+
+	for found := true; found; {
+		found = false
+
+		for _, objectPath := range server.order {
+			object := server.objects[objectPath]
+			if removed[objectPath] {
+				continue
+			}
+
+			containerId, _ := object["containerId"].(string)
+			parentId, _ := object["parentId"].(string)
+			if strings.HasPrefix(objectPath, path+"/") || removedIds[containerId] || removedIds[parentId] {
+				removed[objectPath] = true
+				if id, ok := object["id"].(string); ok {
+					removedIds[id] = true
+				}
+				found = true
+			}
+		}
+	}
+
+	order := []string{}
+	for _, objectPath := range server.order {
+		if removed[objectPath] {
+			delete(server.objects, objectPath)
+		} else {
+			order = append(order, objectPath)
+		}
+	}
+
+	server.order = order
+}
+
+// list returns copies of the objects stored directly under collectionPath, in the order they were created
+func (server *Server) list(collectionPath string, include func(map[string]interface{}) bool) []map[string]interface{} {
+	objects := []map[string]interface{}{}
+	for _, path := range server.order {
+		if !strings.HasPrefix(path, collectionPath) || strings.Contains(path[len(collectionPath):], "/") {
+			continue
+		}
+
+		object := server.objects[path]
+		if include == nil || include(object) {
+			objects = append(objects, copyObject(object))
+		}
+	}
+
+	return objects
+}
+
+func copyObject(object map[string]interface{}) map[string]interface{} {
+	encoded, _ := json.Marshal(object)
+
+	var copied map[string]interface{}
+	json.Unmarshal(encoded, &copied)
+
+	return copied
+}
+
+var queryParameters = map[string]bool{
+	"first":               true,
+	"max":                 true,
+	"exact":               true,
+	"search":              true,
+	"q":                   true,
+	"briefRepresentation": true,
+	"populateHierarchy":   true,
+}
+
+// filter applies the query parameters that match an attribute of the objects. Matches are exact when exact=true is
+// given, and case-insensitive substring matches otherwise, like Keycloak's user search. search matches any name.
+func filter(objects []map[string]interface{}, query url.Values) []map[string]interface{} {
+	exact := query.Get("exact") == "true"
+
+	matches := func(value interface{}, expected string) bool {
+		actual, ok := value.(string)
+		if !ok {
+			return fmt.Sprint(value) == expected
+		}
+
+		if exact {
+			return actual == expected
+		}
+
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(expected))
+	}
+
+	return filterBy(objects, func(object map[string]interface{}) bool {
+		for key := range query {
+			if queryParameters[key] {
+				continue
+			}
+
+			if !matches(object[key], query.Get(key)) {
+				return false
+			}
+		}
+
+		if search := query.Get("search"); search != "" {
+			for _, key := range []string{"name", "username", "email", "clientId", "firstName", "lastName"} {
+				if value, ok := object[key].(string); ok && strings.Contains(strings.ToLower(value), strings.ToLower(search)) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		return true
+	})
+}
+
+func filterBy(objects []map[string]interface{}, include func(map[string]interface{}) bool) []map[string]interface{} {
+	filtered := []map[string]interface{}{}
+	for _, object := range objects {
+		if include(object) {
+			filtered = append(filtered, object)
+		}
+	}
+
+	return filtered
+}
+
+func paginate(objects []map[string]interface{}, query url.Values) []map[string]interface{} {
+	if first, err := strconv.Atoi(query.Get("first")); err == nil && first > 0 {
+		if first >= len(objects) {
+			return []map[string]interface{}{}
+		}
+
+		objects = objects[first:]
+	}
+
+	if max, err := strconv.Atoi(query.Get("max")); err == nil && max >= 0 && max < len(objects) {
+		objects = objects[:max]
+	}
+
+	return objects
+}
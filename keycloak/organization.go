@@ -2,7 +2,10 @@ package keycloak
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 )
 
 type Organization struct {
@@ -38,14 +41,55 @@ func (keycloakClient *KeycloakClient) GetOrganization(ctx context.Context, realm
 	return organization, nil
 }
 
+// The organization extension caps the number of organizations it returns when first/max are not given, so every list of
+// organizations is walked page by page.
+const organizationPageSize = 100
+
+type OrganizationSearch struct {
+	// matched against the name of the organization by Keycloak
+	Search string
+	// organizations must have every one of these attributes, with the given value
+	Attributes map[string]string
+}
+
 func (keycloakClient *KeycloakClient) GetOrganizations(ctx context.Context, realmName string) ([]*Organization, error) {
+	return keycloakClient.SearchOrganizations(ctx, realmName, &OrganizationSearch{})
+}
+
+func (keycloakClient *KeycloakClient) SearchOrganizations(ctx context.Context, realmName string, search *OrganizationSearch) ([]*Organization, error) {
 	var organizations []*Organization
 
-	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/orgs", realmName), &organizations, nil)
+	params := map[string]string{}
+	if search.Search != "" {
+		params["search"] = search.Search
+	}
+	if len(search.Attributes) != 0 {
+		var query []string
+		for key, value := range search.Attributes {
+			query = append(query, fmt.Sprintf("%s:%s", key, value))
+		}
+		sort.Strings(query)
+		params["q"] = strings.Join(query, " ")
+	}
+
+	err := keycloakClient.getRootPaginated(ctx, fmt.Sprintf("/realms/%s/orgs", realmName), params, organizationPageSize, func(body []byte) (int, error) {
+		var page []*Organization
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+
+		organizations = append(organizations, page...)
+
+		return len(page), nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	for _, organization := range organizations {
+		organization.RealmName = realmName
+	}
+
 	return organizations, nil
 }
 
@@ -0,0 +1,99 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+type OrganizationDomain struct {
+	DomainName  string `json:"domain_name"`
+	Verified    bool   `json:"verified"`
+	RecordKey   string `json:"record_key"`
+	RecordValue string `json:"record_value"`
+	RecordType  string `json:"type"`
+}
+
+// The domains of an organization are stored on the organization itself, so adding or removing one is a read-modify-write
+// of the whole organization. This keeps concurrent changes to the domains of the same organization from clobbering each other.
+var organizationDomainsMutex sync.Mutex
+
+func (keycloakClient *KeycloakClient) GetOrganizationDomain(ctx context.Context, realmName, orgId, domainName string) (*OrganizationDomain, error) {
+	var domain *OrganizationDomain
+
+	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/domains/%s", realmName, orgId, domainName), &domain, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationDomains(ctx context.Context, realmName, orgId string) ([]*OrganizationDomain, error) {
+	var domains []*OrganizationDomain
+
+	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/domains", realmName, orgId), &domains, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return domains, nil
+}
+
+func (keycloakClient *KeycloakClient) AddOrganizationDomain(ctx context.Context, realmName, orgId, domainName string) error {
+	organizationDomainsMutex.Lock()
+	defer organizationDomainsMutex.Unlock()
+
+	organization, err := keycloakClient.GetOrganization(ctx, realmName, orgId)
+	if err != nil {
+		return err
+	}
+
+	for _, domain := range organization.Domains {
+		if domain == domainName {
+			return nil
+		}
+	}
+
+	organization.RealmName = realmName
+	organization.Domains = append(organization.Domains, domainName)
+
+	return keycloakClient.UpdateOrganization(ctx, organization)
+}
+
+func (keycloakClient *KeycloakClient) RemoveOrganizationDomain(ctx context.Context, realmName, orgId, domainName string) error {
+	organizationDomainsMutex.Lock()
+	defer organizationDomainsMutex.Unlock()
+
+	organization, err := keycloakClient.GetOrganization(ctx, realmName, orgId)
+	if err != nil {
+		return err
+	}
+
+	domains := make([]string, 0, len(organization.Domains))
+	for _, domain := range organization.Domains {
+		if domain != domainName {
+			domains = append(domains, domain)
+		}
+	}
+
+	if len(domains) == len(organization.Domains) {
+		return nil
+	}
+
+	organization.RealmName = realmName
+	organization.Domains = domains
+
+	return keycloakClient.UpdateOrganization(ctx, organization)
+}
+
+// VerifyOrganizationDomain asks Keycloak to check the DNS TXT record of the domain. The returned domain reflects the outcome
+// of the check, it is not an error for the domain to remain unverified.
+func (keycloakClient *KeycloakClient) VerifyOrganizationDomain(ctx context.Context, realmName, orgId, domainName string) (*OrganizationDomain, error) {
+	_, _, err := keycloakClient.postRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/domains/%s/verify", realmName, orgId, domainName), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return keycloakClient.GetOrganizationDomain(ctx, realmName, orgId, domainName)
+}
@@ -0,0 +1,126 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	homeIdpDiscoveryConfigKeyPrefix              = "home.idp.discovery."
+	organizationIdentityProviderDomainsConfigKey = homeIdpDiscoveryConfigKeyPrefix + "domains"
+	organizationIdentityProviderDomainsSeparator = "##"
+)
+
+type OrganizationIdentityProviderLink struct {
+	Alias          string `json:"alias"`
+	SyncMode       string `json:"sync_mode,omitempty"`
+	PostBrokerFlow string `json:"post_broker_flow,omitempty"`
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationIdentityProviders(ctx context.Context, realmName, orgId string) ([]*IdentityProvider, error) {
+	var identityProviders []*IdentityProvider
+
+	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/idps", realmName, orgId), &identityProviders, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityProvider := range identityProviders {
+		identityProvider.Realm = realmName
+	}
+
+	return identityProviders, nil
+}
+
+// GetOrganizationIdentityProvider returns the identity provider with the given alias if it is linked to the organization,
+// and a 404 ApiError otherwise.
+func (keycloakClient *KeycloakClient) GetOrganizationIdentityProvider(ctx context.Context, realmName, orgId, alias string) (*IdentityProvider, error) {
+	identityProviders, err := keycloakClient.GetOrganizationIdentityProviders(ctx, realmName, orgId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityProvider := range identityProviders {
+		if identityProvider.Alias == alias {
+			return identityProvider, nil
+		}
+	}
+
+	return nil, &ApiError{
+		Code:    http.StatusNotFound,
+		Message: fmt.Sprintf("identity provider %s is not linked to organization %s", alias, orgId),
+	}
+}
+
+func (keycloakClient *KeycloakClient) LinkOrganizationIdentityProvider(ctx context.Context, realmName, orgId string, link *OrganizationIdentityProviderLink) error {
+	_, _, err := keycloakClient.postRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/idps/link", realmName, orgId), link)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) UnlinkOrganizationIdentityProvider(ctx context.Context, realmName, orgId, alias string) error {
+	_, _, err := keycloakClient.postRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/idps/%s/unlink", realmName, orgId, alias), nil)
+
+	return err
+}
+
+// GetHomeIdpDiscoveryDomains returns the email domains that home IdP discovery routes to this identity provider.
+func (identityProvider *IdentityProvider) GetHomeIdpDiscoveryDomains() []string {
+	if identityProvider.Config == nil {
+		return nil
+	}
+
+	domains, ok := identityProvider.Config.ExtraConfig[organizationIdentityProviderDomainsConfigKey].(string)
+	if !ok || domains == "" {
+		return nil
+	}
+
+	return strings.Split(domains, organizationIdentityProviderDomainsSeparator)
+}
+
+// SetHomeIdpDiscoveryDomains updates the identity provider so that home IdP discovery routes users with an email address
+// in one of the given domains to it.
+func (keycloakClient *KeycloakClient) SetHomeIdpDiscoveryDomains(ctx context.Context, realmName, alias string, domains []string) error {
+	identityProvider, err := keycloakClient.GetIdentityProvider(ctx, realmName, alias)
+	if err != nil {
+		return err
+	}
+
+	if identityProvider.Config == nil {
+		identityProvider.Config = &IdentityProviderConfig{}
+	}
+	if identityProvider.Config.ExtraConfig == nil {
+		identityProvider.Config.ExtraConfig = map[string]interface{}{}
+	}
+
+	identityProvider.Config.ExtraConfig[organizationIdentityProviderDomainsConfigKey] = strings.Join(domains, organizationIdentityProviderDomainsSeparator)
+
+	return keycloakClient.UpdateIdentityProvider(ctx, identityProvider)
+}
+
+// KeepHomeIdpDiscoveryConfig copies the home IdP discovery config of the current representation of an identity provider
+// that the identity provider doesn't set itself, so updating it doesn't remove the domains that were set with
+// SetHomeIdpDiscoveryDomains.
+func (identityProvider *IdentityProvider) KeepHomeIdpDiscoveryConfig(current *IdentityProvider) {
+	if current.Config == nil {
+		return
+	}
+
+	for key, value := range current.Config.ExtraConfig {
+		if !strings.HasPrefix(key, homeIdpDiscoveryConfigKeyPrefix) {
+			continue
+		}
+
+		if identityProvider.Config == nil {
+			identityProvider.Config = &IdentityProviderConfig{}
+		}
+		if identityProvider.Config.ExtraConfig == nil {
+			identityProvider.Config.ExtraConfig = map[string]interface{}{}
+		}
+		if _, ok := identityProvider.Config.ExtraConfig[key]; !ok {
+			identityProvider.Config.ExtraConfig[key] = value
+		}
+	}
+}
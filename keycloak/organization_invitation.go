@@ -0,0 +1,76 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
+)
+
+type OrganizationInvitation struct {
+	Id             string                  `json:"id,omitempty"`
+	Email          string                  `json:"email"`
+	InviterId      string                  `json:"inviterId,omitempty"`
+	OrganizationId string                  `json:"organizationId,omitempty"`
+	Roles          []string                `json:"roles"`
+	RedirectUri    string                  `json:"redirectUri,omitempty"`
+	CreatedAt      types.KeycloakTimestamp `json:"createdAt,omitempty"`
+	// only used when creating the invitation, to ask Keycloak to email it to the invitee
+	Send bool `json:"send,omitempty"`
+}
+
+func (keycloakClient *KeycloakClient) NewOrganizationInvitation(ctx context.Context, realmName, orgId string, invitation *OrganizationInvitation) error {
+	_, location, err := keycloakClient.postRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/invitations", realmName, orgId), invitation)
+	if err != nil {
+		return err
+	}
+
+	invitation.Id = getIdFromLocationHeader(location)
+	invitation.OrganizationId = orgId
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationInvitation(ctx context.Context, realmName, orgId, invitationId string) (*OrganizationInvitation, error) {
+	var invitation *OrganizationInvitation
+
+	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/invitations/%s", realmName, orgId, invitationId), &invitation, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation.OrganizationId = orgId
+
+	return invitation, nil
+}
+
+// GetOrganizationInvitations returns the pending invitations of an organization. Invitations are removed by Keycloak once
+// they are accepted.
+func (keycloakClient *KeycloakClient) GetOrganizationInvitations(ctx context.Context, realmName, orgId string) ([]*OrganizationInvitation, error) {
+	var invitations []*OrganizationInvitation
+
+	err := keycloakClient.getRootPaginated(ctx, fmt.Sprintf("/realms/%s/orgs/%s/invitations", realmName, orgId), nil, organizationPageSize, func(body []byte) (int, error) {
+		var page []*OrganizationInvitation
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+
+		invitations = append(invitations, page...)
+
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, invitation := range invitations {
+		invitation.OrganizationId = orgId
+	}
+
+	return invitations, nil
+}
+
+func (keycloakClient *KeycloakClient) DeleteOrganizationInvitation(ctx context.Context, realmName, orgId, invitationId string) error {
+	return keycloakClient.deleteRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/invitations/%s", realmName, orgId, invitationId), nil)
+}
@@ -0,0 +1,83 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+func (keycloakClient *KeycloakClient) getOrganizationUsers(ctx context.Context, realmName, path string) ([]*User, error) {
+	var users []*User
+
+	err := keycloakClient.getRootPaginated(ctx, path, nil, organizationPageSize, func(body []byte) (int, error) {
+		var page []*User
+		if err := json.Unmarshal(body, &page); err != nil {
+			return 0, err
+		}
+
+		users = append(users, page...)
+
+		return len(page), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		user.RealmId = realmName
+	}
+
+	return users, nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationMembers(ctx context.Context, realmName, orgId string) ([]*User, error) {
+	return keycloakClient.getOrganizationUsers(ctx, realmName, fmt.Sprintf("/realms/%s/orgs/%s/members", realmName, orgId))
+}
+
+func (keycloakClient *KeycloakClient) AddUsersToOrganization(ctx context.Context, realmName, orgId string, userIds []string) error {
+	for _, userId := range userIds {
+		err := keycloakClient.putRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/members/%s", realmName, orgId, userId), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) RemoveUsersFromOrganization(ctx context.Context, realmName, orgId string, userIds []string) error {
+	for _, userId := range userIds {
+		err := keycloakClient.deleteRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/members/%s", realmName, orgId, userId), nil)
+		if err != nil && !ErrorIs404(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) GetOrganizationRoleUsers(ctx context.Context, realmName, orgId, roleName string) ([]*User, error) {
+	return keycloakClient.getOrganizationUsers(ctx, realmName, fmt.Sprintf("/realms/%s/orgs/%s/roles/%s/users", realmName, orgId, roleName))
+}
+
+func (keycloakClient *KeycloakClient) GrantOrganizationRoleToUsers(ctx context.Context, realmName, orgId, roleName string, userIds []string) error {
+	for _, userId := range userIds {
+		err := keycloakClient.putRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/roles/%s/users/%s", realmName, orgId, roleName, userId), nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (keycloakClient *KeycloakClient) RevokeOrganizationRoleFromUsers(ctx context.Context, realmName, orgId, roleName string, userIds []string) error {
+	for _, userId := range userIds {
+		err := keycloakClient.deleteRoot(ctx, fmt.Sprintf("/realms/%s/orgs/%s/roles/%s/users/%s", realmName, orgId, roleName, userId), nil)
+		if err != nil && !ErrorIs404(err) {
+			return err
+		}
+	}
+
+	return nil
+}
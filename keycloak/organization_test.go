@@ -0,0 +1,88 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSearchOrganizationsWalksEveryPage(t *testing.T) {
+	total := organizationPageSize*2 + 5
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.URL.Path != "/realms/foo/orgs" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("search") != "acme" || r.URL.Query().Get("q") != "region:eu tier:gold" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+
+		first, _ := strconv.Atoi(r.URL.Query().Get("first"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("max"))
+
+		page := []*Organization{}
+		for i := first; i < first+pageSize && i < total; i++ {
+			page = append(page, &Organization{Id: fmt.Sprintf("org-%d", i)})
+		}
+
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	organizations, err := keycloakClient.SearchOrganizations(context.Background(), "foo", &OrganizationSearch{
+		Search:     "acme",
+		Attributes: map[string]string{"tier": "gold", "region": "eu"},
+	})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(organizations) != total {
+		t.Fatalf("expected %d organizations, got %d", total, len(organizations))
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 pages to be requested, got %d", requests)
+	}
+
+	if organizations[total-1].Id != fmt.Sprintf("org-%d", total-1) || organizations[0].RealmName != "foo" {
+		t.Fatalf("unexpected organization %v", organizations[total-1])
+	}
+}
+
+func TestKeepHomeIdpDiscoveryConfig(t *testing.T) {
+	current := &IdentityProvider{Config: &IdentityProviderConfig{ExtraConfig: map[string]interface{}{
+		organizationIdentityProviderDomainsConfigKey: "example.com##example.org",
+		"clientAuthMethod":                           "client_secret_post",
+	}}}
+
+	identityProvider := &IdentityProvider{Config: &IdentityProviderConfig{}}
+	identityProvider.KeepHomeIdpDiscoveryConfig(current)
+
+	if domains := identityProvider.GetHomeIdpDiscoveryDomains(); len(domains) != 2 {
+		t.Fatalf("expected the domains to be kept, got %v", domains)
+	}
+	if _, ok := identityProvider.Config.ExtraConfig["clientAuthMethod"]; ok {
+		t.Fatalf("expected other config to be left to the identity provider, got %v", identityProvider.Config.ExtraConfig)
+	}
+
+	// config that is set explicitly wins
+	identityProvider = &IdentityProvider{Config: &IdentityProviderConfig{ExtraConfig: map[string]interface{}{
+		organizationIdentityProviderDomainsConfigKey: "",
+	}}}
+	identityProvider.KeepHomeIdpDiscoveryConfig(current)
+
+	if domains := identityProvider.GetHomeIdpDiscoveryDomains(); len(domains) != 0 {
+		t.Fatalf("expected the domains to be removed, got %v", domains)
+	}
+}
@@ -0,0 +1,150 @@
+package keycloak
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// The responses of list endpoints are cached for the lifetime of the provider when the read cache is enabled, so the
+// lookups that resources do during a plan, such as finding a client by its client id, don't fetch the same collection
+// again for every resource. Entries are kept per realm and collection, and are dropped when a request that changes
+// the same realm and collection, or a collection that depends on it, is sent.
+
+// Only GET requests whose path ends with one of these segments are cached
+var readCacheListSegments = map[string]bool{
+	"serverinfo":                     true,
+	"realms":                         true,
+	"clients":                        true,
+	"client-scopes":                  true,
+	"default-default-client-scopes":  true,
+	"default-optional-client-scopes": true,
+	"roles":                          true,
+	"groups":                         true,
+	"children":                       true,
+	"default-groups":                 true,
+	"components":                     true,
+	"instances":                      true,
+	"orgs":                           true,
+}
+
+// Writes to the collections on the left can change the responses of the collections on the right as well
+var readCacheDependencies = map[string][]string{
+	"clients":       {"roles", "users", "client-scopes"},
+	"client-scopes": {"clients", "default-default-client-scopes", "default-optional-client-scopes"},
+	"roles":         {"roles-by-id", "clients", "groups", "users"},
+	"roles-by-id":   {"roles", "clients", "groups", "users"},
+	"groups":        {"users", "default-groups"},
+	"users":         {"groups"},
+	"partialImport": {"clients", "client-scopes", "roles", "roles-by-id", "groups", "default-groups", "users", "components"},
+}
+
+type readCacheEntry struct {
+	realm      string
+	collection string
+	body       []byte
+}
+
+type readCache struct {
+	entries    map[string]*readCacheEntry
+	generation uint64
+	mutex      sync.RWMutex
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		entries: map[string]*readCacheEntry{},
+	}
+}
+
+// readCacheScope returns the realm and collection that a request path belongs to, such as "foo" and "clients" for
+// /admin/realms/foo/clients/123/roles. Paths outside of a realm, like serverinfo, are their own collection.
+func readCacheScope(path string) (string, string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i, segment := range segments {
+		if segment != "realms" {
+			continue
+		}
+
+		realm, collection := "", ""
+		if i+1 < len(segments) {
+			realm = segments[i+1]
+		}
+		if i+2 < len(segments) {
+			collection = segments[i+2]
+		}
+
+		return realm, collection
+	}
+
+	return "", segments[len(segments)-1]
+}
+
+func isCacheableRead(requestUrl *url.URL) bool {
+	path := strings.TrimSuffix(requestUrl.Path, "/")
+
+	return readCacheListSegments[path[strings.LastIndex(path, "/")+1:]]
+}
+
+func (cache *readCache) get(requestUrl *url.URL) ([]byte, uint64, bool) {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+
+	entry, ok := cache.entries[requestUrl.RequestURI()]
+	if !ok {
+		return nil, cache.generation, false
+	}
+
+	return entry.body, cache.generation, true
+}
+
+// put stores a response, unless something was invalidated since the request was sent, since the response could be
+// stale in that case.
+func (cache *readCache) put(requestUrl *url.URL, body []byte, generation uint64) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if generation != cache.generation {
+		return
+	}
+
+	realm, collection := readCacheScope(requestUrl.Path)
+
+	cache.entries[requestUrl.RequestURI()] = &readCacheEntry{
+		realm:      realm,
+		collection: collection,
+		body:       body,
+	}
+}
+
+func (cache *readCache) invalidate(requestUrl *url.URL) {
+	realm, collection := readCacheScope(requestUrl.Path)
+
+	collections := map[string]bool{
+		collection: true,
+	}
+	for _, dependency := range readCacheDependencies[collection] {
+		collections[dependency] = true
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.generation++
+
+	for key, entry := range cache.entries {
+		switch {
+		case realm == "":
+			// creating a realm, or anything else outside of a realm
+			delete(cache.entries, key)
+		case collection == "":
+			// changing the realm itself, which can change anything in it, as well as the list of realms
+			if entry.realm == realm || (entry.realm == "" && entry.collection == "") {
+				delete(cache.entries, key)
+			}
+		case entry.realm == realm && collections[entry.collection]:
+			delete(cache.entries, key)
+		}
+	}
+}
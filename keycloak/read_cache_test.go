@@ -0,0 +1,117 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+)
+
+func TestReadCacheScope(t *testing.T) {
+	tests := map[string][2]string{
+		"/admin/realms":                          {"", ""},
+		"/auth/admin/realms/foo":                 {"foo", ""},
+		"/admin/realms/foo/clients":              {"foo", "clients"},
+		"/admin/realms/foo/clients/123/roles":    {"foo", "clients"},
+		"/realms/foo/orgs/123/members":           {"foo", "orgs"},
+		"/admin/serverinfo":                      {"", "serverinfo"},
+		"/auth/admin/realms/foo/roles-by-id/456": {"foo", "roles-by-id"},
+	}
+
+	for path, expected := range tests {
+		realm, collection := readCacheScope(path)
+		if realm != expected[0] || collection != expected[1] {
+			t.Errorf("expected %s to belong to realm %q and collection %q, got %q and %q", path, expected[0], expected[1], realm, collection)
+		}
+	}
+}
+
+func TestReadCacheInvalidatesOnWrite(t *testing.T) {
+	var mutex sync.Mutex
+	requests := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests[r.Method+" "+r.URL.Path]++
+		mutex.Unlock()
+
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`[]`))
+		} else {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{})
+	keycloakClient.readCache = newReadCache()
+
+	ctx := context.Background()
+	get := func(path string) {
+		var result []interface{}
+		if err := keycloakClient.get(ctx, path, &result, nil); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	expectRequests := func(path string, expected int) {
+		if requests["GET /admin"+path] != expected {
+			t.Fatalf("expected %d requests to %s, got %d", expected, path, requests["GET /admin"+path])
+		}
+	}
+
+	get("/realms/foo/clients")
+	get("/realms/foo/clients")
+	get("/realms/foo/groups")
+	expectRequests("/realms/foo/clients", 1)
+
+	// changing a client drops the cached clients, but not the groups
+	if err := keycloakClient.put(ctx, "/realms/foo/clients/123", map[string]string{}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	get("/realms/foo/clients")
+	get("/realms/foo/groups")
+	expectRequests("/realms/foo/clients", 2)
+	expectRequests("/realms/foo/groups", 1)
+
+	// the same collection in another realm is unaffected
+	get("/realms/bar/clients")
+	if err := keycloakClient.delete(ctx, "/realms/foo/clients/123", nil); err != nil {
+		t.Fatalf("%s", err)
+	}
+	get("/realms/bar/clients")
+	expectRequests("/realms/bar/clients", 1)
+
+	// changing the realm drops everything cached for it
+	if err := keycloakClient.put(ctx, "/realms/foo", map[string]string{}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	get("/realms/foo/groups")
+	expectRequests("/realms/foo/groups", 2)
+
+	// objects are always fetched, only lists are cached
+	for i := 0; i < 2; i++ {
+		if _, err := keycloakClient.getRaw(ctx, "/realms/foo/clients/123", nil); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+	expectRequests("/realms/foo/clients/123", 2)
+}
+
+func TestReadCacheIgnoresResponsesReadDuringWrites(t *testing.T) {
+	cache := newReadCache()
+	requestUrl, _ := url.Parse("http://localhost/admin/realms/foo/clients")
+
+	_, generation, _ := cache.get(requestUrl)
+
+	cache.invalidate(&url.URL{Path: "/admin/realms/foo/clients/123"})
+	cache.put(requestUrl, []byte(`[]`), generation)
+
+	if _, _, ok := cache.get(requestUrl); ok {
+		t.Fatalf("expected a response read while the collection was changed to not be cached")
+	}
+}
@@ -0,0 +1,71 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+type RealmClientPolicyExecutor struct {
+	Executor      string                 `json:"executor"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientProfile struct {
+	Name        string                       `json:"name"`
+	Description string                       `json:"description,omitempty"`
+	Executors   []*RealmClientPolicyExecutor `json:"executors"`
+}
+
+type RealmClientProfiles struct {
+	Profiles []*RealmClientProfile `json:"profiles"`
+}
+
+type RealmClientPolicyCondition struct {
+	Condition     string                 `json:"condition"`
+	Configuration map[string]interface{} `json:"configuration"`
+}
+
+type RealmClientPolicy struct {
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	Enabled     bool                          `json:"enabled"`
+	Conditions  []*RealmClientPolicyCondition `json:"conditions"`
+	Profiles    []string                      `json:"profiles"`
+}
+
+type RealmClientPolicies struct {
+	Policies []*RealmClientPolicy `json:"policies"`
+}
+
+// The global profiles and policies that ship with Keycloak are only returned when requested, so these endpoints only
+// deal with the ones that belong to the realm.
+
+func (keycloakClient *KeycloakClient) GetRealmClientProfiles(ctx context.Context, realmId string) (*RealmClientProfiles, error) {
+	var realmClientProfiles RealmClientProfiles
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), &realmClientProfiles, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &realmClientProfiles, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientProfiles(ctx context.Context, realmId string, realmClientProfiles *RealmClientProfiles) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/profiles", realmId), realmClientProfiles)
+}
+
+func (keycloakClient *KeycloakClient) GetRealmClientPolicies(ctx context.Context, realmId string) (*RealmClientPolicies, error) {
+	var realmClientPolicies RealmClientPolicies
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), &realmClientPolicies, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &realmClientPolicies, nil
+}
+
+func (keycloakClient *KeycloakClient) UpdateRealmClientPolicies(ctx context.Context, realmId string, realmClientPolicies *RealmClientPolicies) error {
+	return keycloakClient.put(ctx, fmt.Sprintf("/realms/%s/client-policies/policies", realmId), realmClientPolicies)
+}
@@ -0,0 +1,156 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	RealmInventoryClient             = "client"
+	RealmInventoryClientScope        = "client_scope"
+	RealmInventoryRole               = "role"
+	RealmInventoryGroup              = "group"
+	RealmInventoryIdentityProvider   = "identity_provider"
+	RealmInventoryAuthenticationFlow = "authentication_flow"
+	RealmInventoryComponent          = "component"
+)
+
+// RealmInventoryTypes are the types of objects in a realm inventory, in the order they are listed
+var RealmInventoryTypes = []string{
+	RealmInventoryClient,
+	RealmInventoryClientScope,
+	RealmInventoryRole,
+	RealmInventoryGroup,
+	RealmInventoryIdentityProvider,
+	RealmInventoryAuthenticationFlow,
+	RealmInventoryComponent,
+}
+
+// RealmInventoryItem is an object of a realm, with the name people know it by, like the client id of a client, the
+// path of a group or the alias of an identity provider
+type RealmInventoryItem struct {
+	Type string
+	Id   string
+	Name string
+	// ParentId is the id of the client of a client role, of the parent of a subgroup, or of the parent of a component
+	// that doesn't belong to the realm itself, like an LDAP mapper
+	ParentId string
+	// ProviderId is the protocol of a client or client scope, or the provider of an identity provider, authentication
+	// flow or component
+	ProviderId string
+}
+
+// GetRealmInventory lists the objects of a realm that have one of the given types, or every object when no types are
+// given. Identity providers are identified by their alias, like they are everywhere else.
+func (keycloakClient *KeycloakClient) GetRealmInventory(ctx context.Context, realmId string, types ...string) ([]*RealmInventoryItem, error) {
+	wanted := map[string]bool{}
+	for _, inventoryType := range types {
+		wanted[inventoryType] = true
+	}
+
+	// clients are needed to list client roles as well
+	var clients []*GenericClient
+	if len(wanted) == 0 || wanted[RealmInventoryClient] || wanted[RealmInventoryRole] {
+		var err error
+
+		clients, err = keycloakClient.listGenericClients(ctx, realmId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var items []*RealmInventoryItem
+
+	for _, inventoryType := range RealmInventoryTypes {
+		if len(wanted) != 0 && !wanted[inventoryType] {
+			continue
+		}
+
+		switch inventoryType {
+		case RealmInventoryClient:
+			for _, client := range clients {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: client.Id, Name: client.ClientId, ProviderId: client.Protocol})
+			}
+		case RealmInventoryClientScope:
+			var clientScopes []*OpenidClientScope
+
+			err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/client-scopes", realmId), &clientScopes, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, clientScope := range clientScopes {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: clientScope.Id, Name: clientScope.Name, ProviderId: clientScope.Protocol})
+			}
+		case RealmInventoryRole:
+			roles, err := keycloakClient.GetRealmRoles(ctx, realmId)
+			if err != nil {
+				return nil, err
+			}
+
+			var openidClients []*OpenidClient
+			for _, client := range clients {
+				openidClients = append(openidClients, &OpenidClient{Id: client.Id})
+			}
+
+			clientRoles, err := keycloakClient.GetClientRoles(ctx, realmId, openidClients)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, role := range append(roles, clientRoles...) {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: role.Id, Name: role.Name, ParentId: role.ClientId})
+			}
+		case RealmInventoryGroup:
+			groups, err := keycloakClient.GetGroupSubtree(ctx, realmId, "", 0)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, group := range groups {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: group.Id, Name: group.Path, ParentId: group.ParentId})
+			}
+		case RealmInventoryIdentityProvider:
+			identityProviders, err := keycloakClient.GetIdentityProviders(ctx, realmId)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, identityProvider := range identityProviders {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: identityProvider.Alias, Name: identityProvider.Alias, ProviderId: identityProvider.ProviderId})
+			}
+		case RealmInventoryAuthenticationFlow:
+			authenticationFlows, err := keycloakClient.ListAuthenticationFlows(ctx, realmId)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, authenticationFlow := range authenticationFlows {
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: authenticationFlow.Id, Name: authenticationFlow.Alias, ProviderId: authenticationFlow.ProviderId})
+			}
+		case RealmInventoryComponent:
+			realm, err := keycloakClient.GetRealm(ctx, realmId)
+			if err != nil {
+				return nil, err
+			}
+
+			var components []*component
+
+			err = keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/components", realmId), &components, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, component := range components {
+				parentId := component.ParentId
+				if parentId == realm.Id || parentId == realmId {
+					parentId = ""
+				}
+
+				items = append(items, &RealmInventoryItem{Type: inventoryType, Id: component.Id, Name: component.Name, ParentId: parentId, ProviderId: component.ProviderId})
+			}
+		}
+	}
+
+	return items, nil
+}
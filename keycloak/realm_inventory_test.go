@@ -0,0 +1,74 @@
+package keycloak
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
+)
+
+func TestGetRealmInventory(t *testing.T) {
+	ctx := context.Background()
+
+	server := keycloaktest.NewServer(t)
+	keycloakClient := newFakeServerClient(t, server)
+
+	if err := keycloakClient.NewRealm(ctx, &Realm{Realm: "inventory", Enabled: true}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	client := &OpenidClient{RealmId: "inventory", ClientId: "my-app", Enabled: true, PublicClient: true}
+	if err := keycloakClient.NewOpenidClient(ctx, client); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := keycloakClient.CreateRole(ctx, &Role{RealmId: "inventory", ClientId: client.Id, Name: "admin"}); err != nil {
+		t.Fatalf("%s", err)
+	}
+	if err := keycloakClient.CreateRole(ctx, &Role{RealmId: "inventory", Name: "reader"}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	parent := &Group{RealmId: "inventory", Name: "parent"}
+	if err := keycloakClient.NewGroup(ctx, parent); err != nil {
+		t.Fatalf("%s", err)
+	}
+	child := &Group{RealmId: "inventory", ParentId: parent.Id, Name: "child"}
+	if err := keycloakClient.NewGroup(ctx, child); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	items, err := keycloakClient.GetRealmInventory(ctx, "inventory")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	expected := []RealmInventoryItem{
+		{Type: RealmInventoryClient, Id: client.Id, Name: "my-app", ProviderId: "openid-connect"},
+		{Type: RealmInventoryRole, Name: "reader"},
+		{Type: RealmInventoryRole, Name: "admin", ParentId: client.Id},
+		{Type: RealmInventoryGroup, Id: parent.Id, Name: "/parent"},
+		{Type: RealmInventoryGroup, Id: child.Id, Name: "/parent/child", ParentId: parent.Id},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("expected %d items, got %d", len(expected), len(items))
+	}
+	for i, item := range items {
+		if item.Id == "" {
+			t.Errorf("expected item %d to have an id", i)
+		}
+		if expected[i].Id == "" {
+			expected[i].Id = item.Id
+		}
+		if *item != expected[i] {
+			t.Errorf("expected item %d to be %+v, got %+v", i, expected[i], *item)
+		}
+	}
+
+	items, err = keycloakClient.GetRealmInventory(ctx, "inventory", RealmInventoryGroup)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(items) != 2 || items[0].Type != RealmInventoryGroup || items[1].Type != RealmInventoryGroup {
+		t.Fatalf("expected only the groups, got %d items", len(items))
+	}
+}
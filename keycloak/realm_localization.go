@@ -0,0 +1,37 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Realm localization texts override the messages of the themes for a single locale. Only the overrides that belong to
+// the realm are returned, the messages of the themes are left out.
+
+func (keycloakClient *KeycloakClient) GetRealmLocalizationTexts(ctx context.Context, realmId, locale string) (map[string]string, error) {
+	texts := map[string]string{}
+
+	err := keycloakClient.get(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), &texts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return texts, nil
+}
+
+// UpdateRealmLocalizationTexts creates or updates the given texts, texts with other keys are left alone.
+func (keycloakClient *KeycloakClient) UpdateRealmLocalizationTexts(ctx context.Context, realmId, locale string, texts map[string]string) error {
+	_, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), texts)
+
+	return err
+}
+
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationText(ctx context.Context, realmId, locale, key string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s/%s", realmId, url.PathEscape(locale), url.PathEscape(key)), nil)
+}
+
+// DeleteRealmLocalizationTexts deletes every text of the locale.
+func (keycloakClient *KeycloakClient) DeleteRealmLocalizationTexts(ctx context.Context, realmId, locale string) error {
+	return keycloakClient.delete(ctx, fmt.Sprintf("/realms/%s/localization/%s", realmId, url.PathEscape(locale)), nil)
+}
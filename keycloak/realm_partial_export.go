@@ -0,0 +1,12 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExportRealm returns the representation of a realm, optionally with its clients, groups and roles. Users are never
+// exported, and Keycloak masks secrets such as client secrets in the representation.
+func (keycloakClient *KeycloakClient) ExportRealm(ctx context.Context, realmId string, exportClients, exportGroupsAndRoles bool) ([]byte, error) {
+	return keycloakClient.sendRaw(ctx, fmt.Sprintf("/realms/%s/partial-export?exportClients=%t&exportGroupsAndRoles=%t", realmId, exportClients, exportGroupsAndRoles), nil)
+}
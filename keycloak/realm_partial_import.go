@@ -0,0 +1,44 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type RealmPartialImportResult struct {
+	Action       string `json:"action"`
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	Id           string `json:"id"`
+}
+
+type RealmPartialImportResponse struct {
+	Overwritten int                         `json:"overwritten"`
+	Added       int                         `json:"added"`
+	Skipped     int                         `json:"skipped"`
+	Results     []*RealmPartialImportResult `json:"results"`
+}
+
+// PartialImportRealm imports the users, clients, groups, roles and identity providers of a realm representation into
+// an existing realm. ifResourceExists is one of FAIL, SKIP or OVERWRITE, and decides what happens to resources that
+// already exist in the realm. Nothing is imported when the policy is FAIL and one of them exists.
+func (keycloakClient *KeycloakClient) PartialImportRealm(ctx context.Context, realmId string, representation map[string]interface{}, ifResourceExists string) (*RealmPartialImportResponse, error) {
+	partialImport := map[string]interface{}{}
+	for key, value := range representation {
+		partialImport[key] = value
+	}
+	partialImport["ifResourceExists"] = ifResourceExists
+
+	body, _, err := keycloakClient.post(ctx, fmt.Sprintf("/realms/%s/partialImport", realmId), partialImport)
+	if err != nil {
+		return nil, err
+	}
+
+	var response RealmPartialImportResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
@@ -0,0 +1,132 @@
+package keycloak
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const redactedValue = "***"
+
+// JSON keys and form fields that are never written to the logs. Keys are matched without regard to case.
+var defaultRedactedLogFields = []string{
+	"password",
+	"secret",
+	"client_secret",
+	"clientSecret",
+	"client_assertion",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"registrationAccessToken",
+	"bindCredential",
+	"privateKey",
+	"keystorePassword",
+	"keyPassword",
+	"secretData",
+	"credentialData",
+}
+
+var defaultLogRedactor = newLogRedactor(nil)
+
+type logRedactor struct {
+	fields map[string]bool
+}
+
+func newLogRedactor(extraFields []string) *logRedactor {
+	fields := map[string]bool{}
+	for _, field := range append(append([]string{}, defaultRedactedLogFields...), extraFields...) {
+		fields[strings.ToLower(field)] = true
+	}
+
+	return &logRedactor{
+		fields: fields,
+	}
+}
+
+func (redactor *logRedactor) isSensitive(field string) bool {
+	return redactor.fields[strings.ToLower(field)]
+}
+
+// redactForm masks the sensitive fields of an url encoded form, such as the body of a token request.
+func (redactor *logRedactor) redactForm(form url.Values) string {
+	redacted := url.Values{}
+	for field, values := range form {
+		if redactor.isSensitive(field) {
+			redacted[field] = []string{redactedValue}
+		} else {
+			redacted[field] = values
+		}
+	}
+
+	return redacted.Encode()
+}
+
+// redactBody masks the sensitive keys of a JSON request or response body. Bodies that aren't JSON are only logged when
+// they don't mention any of the sensitive keys.
+func (redactor *logRedactor) redactBody(body []byte) string {
+	var document interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&document); err != nil {
+		lowerBody := strings.ToLower(string(body))
+		for field := range redactor.fields {
+			if strings.Contains(lowerBody, field) {
+				return fmt.Sprintf("[%d bytes, not logged as it may contain sensitive values]", len(body))
+			}
+		}
+
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactor.redactValue(document))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes, not logged as it may contain sensitive values]", len(body))
+	}
+
+	return string(redacted)
+}
+
+func (redactor *logRedactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// credentials, such as the ones used to set a user's password, keep their secret in "value"
+		isCredential := false
+		if credentialType, ok := v["type"].(string); ok && redactor.isSensitive(credentialType) {
+			isCredential = true
+		}
+
+		redacted := make(map[string]interface{}, len(v))
+		for key, nested := range v {
+			if redactor.isSensitive(key) || (isCredential && key == "value") {
+				redacted[key] = redactedValue
+			} else {
+				redacted[key] = redactor.redactValue(nested)
+			}
+		}
+
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, nested := range v {
+			redacted[i] = redactor.redactValue(nested)
+		}
+
+		return redacted
+	}
+
+	return value
+}
+
+// Clients that weren't created with NewKeycloakClient still redact the default fields
+func (keycloakClient *KeycloakClient) getLogRedactor() *logRedactor {
+	if keycloakClient.logRedactor == nil {
+		return defaultLogRedactor
+	}
+
+	return keycloakClient.logRedactor
+}
@@ -0,0 +1,138 @@
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RetryPolicy controls how requests to the Keycloak API are retried when the server returns a 5xx or 429 response, or
+// when the connection to the server fails.
+type RetryPolicy struct {
+	MaxRetries         int
+	MinBackoff         time.Duration
+	MaxBackoff         time.Duration
+	Jitter             bool
+	RetryNonIdempotent bool
+}
+
+type nonIdempotentRequestContextKey struct{}
+
+// Marks a request as non-idempotent, so it is only retried when the server definitely did not process it
+func withNonIdempotentRequest(request *http.Request) *http.Request {
+	return request.WithContext(context.WithValue(request.Context(), nonIdempotentRequestContextKey{}, true))
+}
+
+func isNonIdempotentRequest(ctx context.Context) bool {
+	nonIdempotent, _ := ctx.Value(nonIdempotentRequestContextKey{}).(bool)
+
+	return nonIdempotent
+}
+
+func (policy RetryPolicy) newRetryClient() *retryablehttp.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = policy.MaxRetries
+	retryClient.RetryWaitMin = policy.MinBackoff
+	retryClient.RetryWaitMax = policy.MaxBackoff
+	retryClient.CheckRetry = policy.checkRetry
+	retryClient.Backoff = policy.backoff
+	// the final response is handled by sendRequest, which turns error responses into an ApiError
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	retryClient.Logger = nil
+	retryClient.RequestLogHook = func(_ retryablehttp.Logger, request *http.Request, attempt int) {
+		if attempt == 0 {
+			return
+		}
+
+		tflog.Debug(request.Context(), "Retrying request", map[string]interface{}{
+			"method":  request.Method,
+			"path":    request.URL.Path,
+			"attempt": attempt,
+		})
+	}
+
+	return retryClient
+}
+
+func (policy RetryPolicy) checkRetry(ctx context.Context, response *http.Response, err error) (bool, error) {
+	shouldRetry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, response, err)
+	if !shouldRetry || checkErr != nil {
+		return false, checkErr
+	}
+
+	if policy.RetryNonIdempotent || !isNonIdempotentRequest(ctx) {
+		return true, nil
+	}
+
+	// POST requests are only retried when we know that Keycloak did not act on the request. A 502 can come from a proxy
+	// that lost the connection after Keycloak received the request, so it isn't enough.
+	if err != nil {
+		var opErr *net.OpError
+		return errors.As(err, &opErr) && opErr.Op == "dial", nil
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (policy RetryPolicy) backoff(min, max time.Duration, attemptNum int, response *http.Response) time.Duration {
+	if response != nil && (response.StatusCode == http.StatusTooManyRequests || response.StatusCode == http.StatusServiceUnavailable) {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok {
+			if retryAfter > max {
+				return max
+			}
+
+			return retryAfter
+		}
+	}
+
+	mult := math.Pow(2, float64(attemptNum)) * float64(min)
+	sleep := time.Duration(mult)
+	if float64(sleep) != mult || sleep > max {
+		sleep = max
+	}
+
+	if policy.Jitter && sleep > min {
+		sleep = min + time.Duration(rand.Int63n(int64(sleep-min)))
+	}
+
+	return sleep
+}
+
+// The Retry-After header can either be a number of seconds or an HTTP date
+func parseRetryAfter(retryAfter string) (time.Duration, bool) {
+	if retryAfter == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,155 @@
+package keycloak
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(t *testing.T, url string, retryPolicy RetryPolicy) *KeycloakClient {
+	httpClient, err := newHttpClient(false, 5, "", "", "", retryPolicy, ConnectionPool{})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return &KeycloakClient{
+		baseUrl: url,
+		clientCredentials: &ClientCredentials{
+			AccessToken: "token",
+			TokenType:   "Bearer",
+		},
+		httpClient: httpClient,
+	}
+}
+
+func TestRetryPolicyRetriesServerErrors(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	var result map[string]interface{}
+	err := keycloakClient.get(context.Background(), "/realms/foo", &result, nil)
+	if err != nil {
+		t.Fatalf("expected request to succeed after retries: %s", err)
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestRetryPolicyReturnsApiErrorWhenRetriesAreExhausted(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, err := keycloakClient.getRaw(context.Background(), "/realms/foo", nil)
+	apiErr, ok := err.(*ApiError)
+	if !ok || apiErr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected ApiError with status 503, got %v", err)
+	}
+
+	if requests != 3 {
+		t.Fatalf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestRetryPolicyDoesNotRetryPostOnInternalServerError(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, _, err := keycloakClient.post(context.Background(), "/realms", map[string]string{"realm": "foo"})
+	if err == nil {
+		t.Fatalf("expected request to fail")
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected POST to be sent once, got %d", requests)
+	}
+
+	requests = 0
+	keycloakClient = newRetryTestClient(t, server.URL, RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond, RetryNonIdempotent: true})
+
+	_, _, err = keycloakClient.post(context.Background(), "/realms", map[string]string{"realm": "foo"})
+	if err == nil {
+		t.Fatalf("expected request to fail")
+	}
+
+	if requests != 4 {
+		t.Fatalf("expected POST to be sent 4 times, got %d", requests)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{}
+	min := time.Second
+	max := 10 * time.Second
+
+	if wait := policy.backoff(min, max, 2, nil); wait != 4*time.Second {
+		t.Fatalf("expected exponential backoff of 4s, got %s", wait)
+	}
+
+	if wait := policy.backoff(min, max, 10, nil); wait != max {
+		t.Fatalf("expected backoff to be capped at %s, got %s", max, wait)
+	}
+
+	response := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	response.Header.Set("Retry-After", "7")
+	if wait := policy.backoff(min, max, 0, response); wait != 7*time.Second {
+		t.Fatalf("expected Retry-After of 7s to be honored, got %s", wait)
+	}
+
+	response.Header.Set("Retry-After", "120")
+	if wait := policy.backoff(min, max, 0, response); wait != max {
+		t.Fatalf("expected Retry-After to be capped at %s, got %s", max, wait)
+	}
+
+	policy.Jitter = true
+	for i := 0; i < 20; i++ {
+		if wait := policy.backoff(min, max, 3, nil); wait < min || wait > 8*time.Second {
+			t.Fatalf("expected jittered backoff between %s and 8s, got %s", min, wait)
+		}
+	}
+}
+
+func TestRetryPolicyDoesNotRetryPostOnBadGateway(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	keycloakClient := newRetryTestClient(t, server.URL, RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	_, _, err := keycloakClient.post(context.Background(), "/realms", map[string]string{"realm": "foo"})
+	if err == nil {
+		t.Fatalf("expected request to fail")
+	}
+
+	if requests != 1 {
+		t.Fatalf("expected POST to be sent once, got %d", requests)
+	}
+}
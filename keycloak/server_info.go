@@ -1,6 +1,9 @@
 package keycloak
 
-import "context"
+import (
+	"context"
+	"strings"
+)
 
 type SystemInfo struct {
 	ServerVersion string `json:"version"`
@@ -23,8 +26,25 @@ type Theme struct {
 	Locales []string `json:"locales,omitempty"`
 }
 
+// ProfileInfo is returned by Keycloak 21 and older
+type ProfileInfo struct {
+	Name                 string   `json:"name"`
+	DisabledFeatures     []string `json:"disabledFeatures"`
+	PreviewFeatures      []string `json:"previewFeatures"`
+	ExperimentalFeatures []string `json:"experimentalFeatures"`
+}
+
+// Feature is returned by Keycloak 22 and newer, and replaces ProfileInfo
+type Feature struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
 type ServerInfo struct {
 	SystemInfo     SystemInfo                 `json:"systemInfo"`
+	ProfileInfo    *ProfileInfo               `json:"profileInfo,omitempty"`
+	Features       []Feature                  `json:"features,omitempty"`
 	ComponentTypes map[string][]ComponentType `json:"componentTypes"`
 	ProviderTypes  map[string]ProviderType    `json:"providers"`
 	Themes         map[string][]Theme         `json:"themes"`
@@ -54,6 +74,38 @@ func (serverInfo *ServerInfo) ComponentTypeIsInstalled(componentType, componentT
 	return false
 }
 
+// FeatureIsEnabled accepts feature names in either form used by Keycloak, such as "declarative-user-profile" or
+// "DECLARATIVE_USER_PROFILE".
+func (serverInfo *ServerInfo) FeatureIsEnabled(name string) bool {
+	name = normalizeFeatureName(name)
+
+	if len(serverInfo.Features) != 0 {
+		for _, feature := range serverInfo.Features {
+			if normalizeFeatureName(feature.Name) == name {
+				return feature.Enabled
+			}
+		}
+
+		return false
+	}
+
+	if serverInfo.ProfileInfo != nil {
+		for _, feature := range serverInfo.ProfileInfo.DisabledFeatures {
+			if normalizeFeatureName(feature) == name {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func normalizeFeatureName(name string) string {
+	return strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
 func (serverInfo *ServerInfo) getInstalledProvidersNames(providerType string) []string {
 	providers := serverInfo.ProviderTypes[providerType].Providers
 	keys := make([]string, 0, len(providers))
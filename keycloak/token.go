@@ -0,0 +1,143 @@
+package keycloak
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Tokens are refreshed this long before they expire, so a request never goes out with a token that expires in flight
+const tokenExpiryLeeway = 10 * time.Second
+
+type tokenClaims struct {
+	ExpiresAt int64 `json:"exp"`
+	IssuedAt  int64 `json:"iat"`
+}
+
+func (clientCredentials *ClientCredentials) setTokens(tokenResponse *ClientCredentials, issuedAt time.Time) {
+	clientCredentials.AccessToken = tokenResponse.AccessToken
+	clientCredentials.RefreshToken = tokenResponse.RefreshToken
+	clientCredentials.TokenType = tokenResponse.TokenType
+	clientCredentials.accessTokenExpiry = getTokenExpiry(tokenResponse.AccessToken, tokenResponse.ExpiresIn, issuedAt)
+	clientCredentials.refreshTokenExpiry = time.Time{}
+	if tokenResponse.RefreshToken != "" {
+		clientCredentials.refreshTokenExpiry = getTokenExpiry(tokenResponse.RefreshToken, tokenResponse.RefreshExpiresIn, issuedAt)
+	}
+}
+
+func (clientCredentials *ClientCredentials) accessTokenIsValid() bool {
+	if clientCredentials.AccessToken == "" {
+		return false
+	}
+
+	return clientCredentials.accessTokenExpiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(clientCredentials.accessTokenExpiry)
+}
+
+func (clientCredentials *ClientCredentials) refreshTokenIsValid() bool {
+	if clientCredentials.RefreshToken == "" {
+		return false
+	}
+
+	return clientCredentials.refreshTokenExpiry.IsZero() || time.Now().Add(tokenExpiryLeeway).Before(clientCredentials.refreshTokenExpiry)
+}
+
+// Works out when a token expires, based on its `exp` and `iat` claims, or on the `expires_in` value returned by the token
+// endpoint when the token can't be decoded. The lifetime of the token is measured from the local time at which it was
+// requested, so a clock difference between Keycloak and the machine running Terraform doesn't matter.
+// A zero time is returned when the expiry is unknown.
+func getTokenExpiry(token string, expiresIn int, issuedAt time.Time) time.Time {
+	if claims, err := decodeTokenClaims(token); err == nil && claims.ExpiresAt != 0 {
+		if claims.IssuedAt != 0 && claims.IssuedAt <= claims.ExpiresAt {
+			return issuedAt.Add(time.Duration(claims.ExpiresAt-claims.IssuedAt) * time.Second)
+		}
+
+		return time.Unix(claims.ExpiresAt, 0)
+	}
+
+	if expiresIn > 0 {
+		return issuedAt.Add(time.Duration(expiresIn) * time.Second)
+	}
+
+	return time.Time{}
+}
+
+func decodeTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, err
+	}
+
+	var claims tokenClaims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+/*
+*
+Runs the configured access token command and stores the token it prints. The command can either print the raw token,
+or a token endpoint style JSON document with `access_token` and optionally `expires_in` and `token_type`.
+The caller must hold the lock on keycloakClient.mutex.
+*/
+func (keycloakClient *KeycloakClient) runAccessTokenCommand(ctx context.Context) error {
+	tflog.Debug(ctx, "Running access token command", map[string]interface{}{
+		"command": keycloakClient.accessTokenCommand[0],
+	})
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.CommandContext(ctx, keycloakClient.accessTokenCommand[0], keycloakClient.accessTokenCommand[1:]...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	issuedAt := time.Now()
+
+	err := command.Run()
+	if err != nil {
+		return fmt.Errorf("access token command failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+
+	tokenResponse := ClientCredentials{
+		AccessToken: output,
+	}
+
+	if strings.HasPrefix(output, "{") {
+		tokenResponse = ClientCredentials{}
+		err = json.Unmarshal([]byte(output), &tokenResponse)
+		if err != nil {
+			return fmt.Errorf("failed to parse the output of the access token command: %v", err)
+		}
+	}
+
+	if tokenResponse.AccessToken == "" {
+		return fmt.Errorf("access token command did not print an access token")
+	}
+
+	if tokenResponse.TokenType == "" {
+		tokenResponse.TokenType = "Bearer"
+	}
+
+	// external tokens are never refreshed, the command is run again instead
+	tokenResponse.RefreshToken = ""
+
+	keycloakClient.clientCredentials.setTokens(&tokenResponse, issuedAt)
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package keycloak
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestToken(lifetime time.Duration) string {
+	now := time.Now()
+	claims, _ := json.Marshal(map[string]int64{
+		"iat": now.Unix(),
+		"exp": now.Add(lifetime).Unix(),
+	})
+
+	return fmt.Sprintf("eyJhbGciOiJub25lIn0.%s.", base64.RawURLEncoding.EncodeToString(claims))
+}
+
+type testTokenServer struct {
+	*httptest.Server
+	grants          sync.Map
+	accessLifetime  time.Duration
+	refreshLifetime time.Duration
+}
+
+func newTestTokenServer(accessLifetime, refreshLifetime time.Duration) *testTokenServer {
+	tokenServer := &testTokenServer{
+		accessLifetime:  accessLifetime,
+		refreshLifetime: refreshLifetime,
+	}
+
+	tokenServer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realms/master/protocol/openid-connect/token" {
+			w.Write([]byte(`{}`))
+			return
+		}
+
+		r.ParseForm()
+		counter, _ := tokenServer.grants.LoadOrStore(r.PostForm.Get("grant_type"), new(int32))
+		atomic.AddInt32(counter.(*int32), 1)
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  newTestToken(tokenServer.accessLifetime),
+			"refresh_token": newTestToken(tokenServer.refreshLifetime),
+			"token_type":    "Bearer",
+		})
+	}))
+
+	return tokenServer
+}
+
+func (tokenServer *testTokenServer) grantCount(grantType string) int32 {
+	counter, ok := tokenServer.grants.Load(grantType)
+	if !ok {
+		return 0
+	}
+
+	return atomic.LoadInt32(counter.(*int32))
+}
+
+func newTokenTestClient(t *testing.T, url string) *KeycloakClient {
+	httpClient, err := newHttpClient(false, 5, "", "", "", RetryPolicy{}, ConnectionPool{})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return &KeycloakClient{
+		baseUrl: url,
+		realm:   "master",
+		clientCredentials: &ClientCredentials{
+			ClientId:  "admin-cli",
+			Username:  "admin",
+			Password:  "password",
+			GrantType: "password",
+		},
+		httpClient: httpClient,
+	}
+}
+
+func TestConcurrentRequestsLogInOnce(t *testing.T) {
+	tokenServer := newTestTokenServer(time.Minute, time.Hour)
+	defer tokenServer.Close()
+
+	keycloakClient := newTokenTestClient(t, tokenServer.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", nil); err != nil {
+				t.Errorf("%s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if count := tokenServer.grantCount("password"); count != 1 {
+		t.Fatalf("expected a single login, got %d", count)
+	}
+}
+
+func TestExpiringAccessTokenIsRefreshedBeforeRequest(t *testing.T) {
+	tokenServer := newTestTokenServer(time.Minute, time.Hour)
+	defer tokenServer.Close()
+
+	keycloakClient := newTokenTestClient(t, tokenServer.URL)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 10; j++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", nil); err != nil {
+					t.Errorf("%s", err)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// move the access token within the refresh leeway, so it has to be refreshed before the next batch
+		keycloakClient.mutex.Lock()
+		keycloakClient.clientCredentials.accessTokenExpiry = time.Now().Add(tokenExpiryLeeway / 2)
+		keycloakClient.mutex.Unlock()
+	}
+
+	if count := tokenServer.grantCount("password"); count != 1 {
+		t.Fatalf("expected a single login, got %d", count)
+	}
+
+	if count := tokenServer.grantCount("refresh_token"); count != 1 {
+		t.Fatalf("expected a single refresh, got %d", count)
+	}
+}
+
+func TestExpiredRefreshTokenFallsBackToLogin(t *testing.T) {
+	tokenServer := newTestTokenServer(time.Second, time.Second)
+	defer tokenServer.Close()
+
+	keycloakClient := newTokenTestClient(t, tokenServer.URL)
+
+	for i := 0; i < 2; i++ {
+		if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", nil); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	if count := tokenServer.grantCount("password"); count != 2 {
+		t.Fatalf("expected two logins, got %d", count)
+	}
+
+	if count := tokenServer.grantCount("refresh_token"); count != 0 {
+		t.Fatalf("expected no refresh, got %d", count)
+	}
+}
+
+func TestGetTokenExpiry(t *testing.T) {
+	issuedAt := time.Now()
+
+	expiry := getTokenExpiry(newTestToken(time.Minute), 0, issuedAt)
+	if expiry.Sub(issuedAt) != time.Minute {
+		t.Fatalf("expected token to expire after a minute, got %s", expiry.Sub(issuedAt))
+	}
+
+	expiry = getTokenExpiry("opaque-token", 300, issuedAt)
+	if expiry.Sub(issuedAt) != 5*time.Minute {
+		t.Fatalf("expected expires_in to be used for opaque tokens, got %s", expiry.Sub(issuedAt))
+	}
+
+	if expiry = getTokenExpiry("opaque-token", 0, issuedAt); !expiry.IsZero() {
+		t.Fatalf("expected unknown expiry, got %s", expiry)
+	}
+}
+
+func TestAccessTokenCommandIsRunAgainWhenTokenExpires(t *testing.T) {
+	var authorizations sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations.Store(r.Header.Get("Authorization"), true)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	invocations := filepath.Join(t.TempDir(), "invocations")
+	script := fmt.Sprintf(`echo run >> %s; printf '{"access_token": "%%s"}' "$(cat %s | wc -l | tr -d ' ')"`, invocations, invocations)
+
+	keycloakClient, err := NewKeycloakClient(context.Background(), server.URL, "", "", "", "master", "", "", false, 5, "", false, "", false, nil, KeycloakClientOptions{AccessTokenCommand: []string{"sh", "-c", script}})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", nil); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	// opaque tokens without expires_in never expire on their own, so force the command to run again
+	keycloakClient.mutex.Lock()
+	keycloakClient.clientCredentials.accessTokenExpiry = time.Now()
+	keycloakClient.mutex.Unlock()
+
+	if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", nil); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	for _, authorization := range []string{"Bearer 1", "Bearer 2"} {
+		if _, ok := authorizations.Load(authorization); !ok {
+			t.Fatalf("expected a request with authorization %q", authorization)
+		}
+	}
+}
+
+func TestExpiredStaticAccessTokenIsNotRenewed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+r.URL.Query().Get("token") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	token := newTestToken(time.Hour)
+	keycloakClient, err := NewKeycloakClient(context.Background(), server.URL, "", "", "", "master", "", "", false, 5, "", false, "", false, nil, KeycloakClientOptions{AccessToken: token})
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, err := keycloakClient.getRaw(context.Background(), "/realms/master", map[string]string{"token": token}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	_, err = keycloakClient.getRaw(context.Background(), "/realms/master", map[string]string{"token": "other"})
+	if err == nil || !strings.Contains(err.Error(), "cannot be renewed") {
+		t.Fatalf("expected rejected static token to fail, got %v", err)
+	}
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// KeycloakTimestamp is a point in time that some Keycloak extensions serialize as milliseconds since the epoch, and others
+// as a formatted date string. It is always marshalled as an RFC 3339 string.
+type KeycloakTimestamp string
+
+func (t KeycloakTimestamp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(t))
+}
+
+func (t *KeycloakTimestamp) UnmarshalJSON(in []byte) error {
+	value := string(in)
+	if value == "null" {
+		*t = ""
+		return nil
+	}
+
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		*t = KeycloakTimestamp(time.UnixMilli(millis).UTC().Format(time.RFC3339))
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(in, &s); err != nil {
+		return err
+	}
+
+	*t = KeycloakTimestamp(s)
+	return nil
+}
@@ -2,6 +2,8 @@ package keycloak
 
 import (
 	"context"
+	"regexp"
+
 	"github.com/hashicorp/go-version"
 )
 
@@ -22,14 +24,50 @@ const (
 	Version_17 Version = "17.0.0"
 	Version_18 Version = "18.0.0"
 	Version_19 Version = "19.0.0"
+	Version_23 Version = "23.0.0"
+	Version_24 Version = "24.0.0"
+	Version_26 Version = "26.0.0"
 )
 
+// matches the suffixes of product builds, such as "7.6.0.GA" for Red Hat SSO or "24.0.3.redhat-00001" for the Red Hat
+// build of Keycloak
+var serverVersionSuffix = regexp.MustCompile(`\.(GA|redhat-.*)$`)
+
+// parseServerVersion parses the version reported by the server, ignoring product build suffixes and pre-release labels, so
+// that "24.0.3.redhat-00001" and "24.0.3" are treated the same way.
+func parseServerVersion(serverVersion string) (*version.Version, error) {
+	v, err := version.NewVersion(serverVersionSuffix.ReplaceAllString(serverVersion, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Core(), nil
+}
+
+func (keycloakClient *KeycloakClient) getServerVersion(ctx context.Context) (*version.Version, error) {
+	keycloakClient.mutex.RLock()
+	serverVersion := keycloakClient.version
+	keycloakClient.mutex.RUnlock()
+
+	if serverVersion != nil {
+		return serverVersion, nil
+	}
+
+	err := keycloakClient.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keycloakClient.mutex.RLock()
+	defer keycloakClient.mutex.RUnlock()
+
+	return keycloakClient.version, nil
+}
+
 func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.getServerVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -37,15 +75,13 @@ func (keycloakClient *KeycloakClient) VersionIsGreaterThanOrEqualTo(ctx context.
 		return false, nil
 	}
 
-	return keycloakClient.version.GreaterThanOrEqual(v), nil
+	return serverVersion.GreaterThanOrEqual(v), nil
 }
 
 func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Context, versionString Version) (bool, error) {
-	if keycloakClient.version == nil {
-		err := keycloakClient.login(ctx)
-		if err != nil {
-			return false, err
-		}
+	serverVersion, err := keycloakClient.getServerVersion(ctx)
+	if err != nil {
+		return false, err
 	}
 
 	v, err := version.NewVersion(string(versionString))
@@ -53,5 +89,5 @@ func (keycloakClient *KeycloakClient) VersionIsLessThanOrEqualTo(ctx context.Con
 		return false, nil
 	}
 
-	return keycloakClient.version.LessThanOrEqual(v), nil
+	return serverVersion.LessThanOrEqual(v), nil
 }
@@ -2,16 +2,33 @@ package keycloak
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
 )
 
 type Webhook struct {
-	Id          string              `json:"id,omitempty"`
-	Enabled		bool 				`json:"enabled"`
-	URL         string              `json:"url"`
-	Secret      string              `json:"secret"`
-	EventTypes  []string            `json:"eventTypes"`
-	RealmName   string              `json:"realm"`
+	Id         string   `json:"id,omitempty"`
+	Enabled    bool     `json:"enabled"`
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"eventTypes"`
+	RealmName  string   `json:"realm"`
+}
+
+type WebhookSend struct {
+	Id        string                  `json:"id"`
+	EventType string                  `json:"event_type"`
+	EventId   string                  `json:"event_id"`
+	Status    int                     `json:"status"`
+	Retries   int                     `json:"retries"`
+	SentAt    types.KeycloakTimestamp `json:"sent_at"`
+}
+
+type webhookTestResult struct {
+	Status int `json:"status"`
 }
 
 func (keycloakClient *KeycloakClient) NewWebhook(ctx context.Context, webhook *Webhook) error {
@@ -54,3 +71,44 @@ func (keycloakClient *KeycloakClient) UpdateWebhook(ctx context.Context, webhook
 func (keycloakClient *KeycloakClient) DeleteWebhook(ctx context.Context, realmName, id string) error {
 	return keycloakClient.deleteRoot(ctx, fmt.Sprintf("/realms/%s/webhooks/%s", realmName, id), nil)
 }
+
+// GetWebhookSends returns the most recent delivery attempts of a webhook, newest first.
+func (keycloakClient *KeycloakClient) GetWebhookSends(ctx context.Context, realmName, webhookId string, max int) ([]*WebhookSend, error) {
+	var sends []*WebhookSend
+
+	params := map[string]string{
+		"first": "0",
+		"max":   strconv.Itoa(max),
+	}
+
+	err := keycloakClient.getRoot(ctx, fmt.Sprintf("/realms/%s/webhooks/%s/sends", realmName, webhookId), &sends, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return sends, nil
+}
+
+// TestWebhook asks Keycloak to send a test event to the webhook, and returns an error if the endpoint did not accept it.
+func (keycloakClient *KeycloakClient) TestWebhook(ctx context.Context, realmName, webhookId string) error {
+	body, _, err := keycloakClient.postRoot(ctx, fmt.Sprintf("/realms/%s/webhooks/%s/test", realmName, webhookId), nil)
+	if err != nil {
+		return err
+	}
+
+	if len(body) == 0 {
+		return nil
+	}
+
+	// older versions of the extension don't describe the outcome of the send, in which case a successful request is enough
+	var result webhookTestResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil
+	}
+
+	if result.Status != 0 && (result.Status < 200 || result.Status > 299) {
+		return fmt.Errorf("webhook %s rejected the test event with HTTP status %d", webhookId, result.Status)
+	}
+
+	return nil
+}
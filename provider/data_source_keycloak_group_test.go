@@ -34,6 +34,30 @@ func TestAccKeycloakDataSourceGroup_basic(t *testing.T) {
 	})
 }
 
+// runs the configuration of TestAccKeycloakDataSourceGroup_basic with the Terraform CLI against the fake Keycloak
+func TestKeycloakDataSourceGroup_fake(t *testing.T) {
+	testRequireFakeKeycloak(t)
+	testRequireTerraform(t)
+
+	group := acctest.RandomWithPrefix("tf-acc")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckKeycloakRoleDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakGroup_basic(group),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakGroupExists("keycloak_group.group"),
+					resource.TestCheckResourceAttrPair("keycloak_group.group", "id", "data.keycloak_group.group", "id"),
+					resource.TestCheckResourceAttrPair("keycloak_group.group", "name", "data.keycloak_group.group", "name"),
+					testAccCheckDataKeycloakGroup("data.keycloak_group.group"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKeycloakDataSourceGroup_nested(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakGroups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakGroupsRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the group whose subgroups are returned, such as /parent/child. Every group of the realm is returned when it isn't set.",
+			},
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return groups whose name contains this search term, ignoring case.",
+			},
+			"max_depth": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				Description:  "The number of levels of subgroups to return. 0 returns all of them.",
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"attributes": {
+							Type:     schema.TypeMap,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakGroupsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	path := data.Get("path").(string)
+	search := strings.ToLower(data.Get("search").(string))
+
+	groups, err := keycloakClient.GetGroupSubtree(ctx, realmId, path, data.Get("max_depth").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var groupsData []interface{}
+	for _, group := range groups {
+		if search != "" && !strings.Contains(strings.ToLower(group.Name), search) {
+			continue
+		}
+
+		attributes := map[string]string{}
+		for key, values := range group.Attributes {
+			attributes[key] = strings.Join(values, MULTIVALUE_ATTRIBUTE_SEPARATOR)
+		}
+
+		groupsData = append(groupsData, map[string]interface{}{
+			"id":         group.Id,
+			"name":       group.Name,
+			"path":       group.Path,
+			"parent_id":  group.ParentId,
+			"attributes": attributes,
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/groups%s", realmId, strings.TrimSuffix(path, "/")))
+	data.Set("groups", groupsData)
+
+	return nil
+}
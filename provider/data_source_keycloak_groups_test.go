@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceGroups_subtree(t *testing.T) {
+	t.Parallel()
+
+	group := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakGroupDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakGroups_subtree(group),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.keycloak_groups.all", "groups.#", "3"),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.all", "groups.0.id", "keycloak_group.child", "id"),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.all", "groups.0.parent_id", "keycloak_group.parent", "id"),
+					resource.TestCheckResourceAttr("data.keycloak_groups.all", "groups.0.path", fmt.Sprintf("/%s/child", group)),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.all", "groups.1.id", "keycloak_group.grandchild", "id"),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.all", "groups.1.parent_id", "keycloak_group.child", "id"),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.all", "groups.2.id", "keycloak_group.sibling", "id"),
+
+					resource.TestCheckResourceAttr("data.keycloak_groups.direct", "groups.#", "2"),
+
+					resource.TestCheckResourceAttr("data.keycloak_groups.search", "groups.#", "1"),
+					resource.TestCheckResourceAttrPair("data.keycloak_groups.search", "groups.0.id", "keycloak_group.grandchild", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakGroups_subtree(group string) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_group" "parent" {
+	name     = "%s"
+	realm_id = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "child" {
+	name      = "child"
+	parent_id = keycloak_group.parent.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "grandchild" {
+	name      = "grandchild"
+	parent_id = keycloak_group.child.id
+	realm_id  = data.keycloak_realm.realm.id
+}
+
+resource "keycloak_group" "sibling" {
+	name      = "sibling"
+	parent_id = keycloak_group.parent.id
+	realm_id  = data.keycloak_realm.realm.id
+
+	depends_on = [
+		keycloak_group.child,
+	]
+}
+
+data "keycloak_groups" "all" {
+	realm_id = data.keycloak_realm.realm.id
+	path     = keycloak_group.parent.path
+
+	depends_on = [
+		keycloak_group.grandchild,
+		keycloak_group.sibling,
+	]
+}
+
+data "keycloak_groups" "direct" {
+	realm_id  = data.keycloak_realm.realm.id
+	path      = keycloak_group.parent.path
+	max_depth = 1
+
+	depends_on = [
+		keycloak_group.grandchild,
+		keycloak_group.sibling,
+	]
+}
+
+data "keycloak_groups" "search" {
+	realm_id = data.keycloak_realm.realm.id
+	path     = keycloak_group.parent.path
+	search   = "GRAND"
+
+	depends_on = [
+		keycloak_group.grandchild,
+		keycloak_group.sibling,
+	]
+}
+	`, testAccRealm.Realm, group)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakOrganizationInvitations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakOrganizationInvitationsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"invitations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"email": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"roles": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+							Computed: true,
+						},
+						"redirect_uri": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"inviter_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakOrganizationInvitationsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	invitations, err := keycloakClient.GetOrganizationInvitations(ctx, realm, orgId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var invitationsData []interface{}
+	for _, invitation := range invitations {
+		invitationsData = append(invitationsData, map[string]interface{}{
+			"id":           invitation.Id,
+			"email":        invitation.Email,
+			"roles":        invitation.Roles,
+			"redirect_uri": invitation.RedirectUri,
+			"inviter_id":   invitation.InviterId,
+			"created_at":   string(invitation.CreatedAt),
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/invitations", realm, orgId))
+	data.Set("invitations", invitationsData)
+
+	return nil
+}
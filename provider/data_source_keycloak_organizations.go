@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakOrganizations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakOrganizationsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return organizations whose name contains this search term.",
+			},
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return organizations that own this domain. The domain is matched after the organizations are fetched, so every organization that matches the other filters is fetched.",
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Only return organizations that have all of these attributes, with the given values.",
+			},
+			"organizations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"display_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"domains": {
+							Type:     schema.TypeSet,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakOrganizationsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	domain := data.Get("domain").(string)
+
+	search := &keycloak.OrganizationSearch{
+		Search:     data.Get("name").(string),
+		Attributes: map[string]string{},
+	}
+	for key, value := range data.Get("attributes").(map[string]interface{}) {
+		search.Attributes[key] = value.(string)
+	}
+
+	organizations, err := keycloakClient.SearchOrganizations(ctx, realm, search)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var organizationsData []interface{}
+	for _, organization := range organizations {
+		if !organizationMatchesSearch(organization, domain, search.Attributes) {
+			continue
+		}
+
+		organizationsData = append(organizationsData, map[string]interface{}{
+			"id":           organization.Id,
+			"name":         organization.Name,
+			"display_name": organization.DisplayName,
+			"domains":      organization.Domains,
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/organizations", realm))
+	data.Set("organizations", organizationsData)
+
+	return nil
+}
+
+// Keycloak already filters on the attributes, this also makes sure the domain and the attributes match exactly
+func organizationMatchesSearch(organization *keycloak.Organization, domain string, attributes map[string]string) bool {
+	if domain != "" {
+		found := false
+		for _, organizationDomain := range organization.Domains {
+			if strings.EqualFold(organizationDomain, domain) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	for key, value := range attributes {
+		if !stringSliceContains(organization.Attributes[key], value) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmExportRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"export_clients": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"export_groups_and_roles": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmExportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	export, err := keycloakClient.ExportRealm(ctx, realmId, data.Get("export_clients").(bool), data.Get("export_groups_and_roles").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the keys are sorted and the document is indented, so exports of the same realm can be compared line by line
+	var representation interface{}
+	if err := json.Unmarshal(export, &representation); err != nil {
+		return diag.FromErr(err)
+	}
+
+	exportJson, err := json.MarshalIndent(representation, "", "  ")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(realmId)
+	data.Set("json", string(exportJson))
+
+	return nil
+}
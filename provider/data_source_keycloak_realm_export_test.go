@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmExport_basic(t *testing.T) {
+	t.Parallel()
+
+	clientId := acctest.RandomWithPrefix("tf-acc")
+	dataSourceName := "data.keycloak_realm_export.export"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmExport_basic(clientId, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "id", testAccRealm.Realm),
+					resource.TestMatchResourceAttr(dataSourceName, "json", regexp.MustCompile(fmt.Sprintf(`"realm": "%s"`, testAccRealm.Realm))),
+					resource.TestCheckResourceAttrWith(dataSourceName, "json", func(value string) error {
+						if regexp.MustCompile(clientId).MatchString(value) {
+							return fmt.Errorf("expected client %s to not be exported", clientId)
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				Config: testDataSourceKeycloakRealmExport_basic(clientId, true),
+				Check:  resource.TestMatchResourceAttr(dataSourceName, "json", regexp.MustCompile(fmt.Sprintf(`"clientId": "%s"`, clientId))),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakRealmExport_basic(clientId string, exportClients bool) string {
+	return fmt.Sprintf(`
+data "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id    = data.keycloak_realm.realm.id
+	client_id   = "%s"
+	access_type = "CONFIDENTIAL"
+}
+
+data "keycloak_realm_export" "export" {
+	realm_id       = keycloak_openid_client.client.realm_id
+	export_clients = %t
+}
+	`, testAccRealm.Realm, clientId, exportClients)
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakRealmInventory() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakRealmInventoryRead,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"types": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validation.StringInSlice(keycloak.RealmInventoryTypes, false)},
+				Optional:    true,
+				Description: "Only list objects of these types. Every type is listed when it isn't set.",
+			},
+			"entries": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"parent_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"provider_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"import_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakRealmInventoryRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	var types []string
+	for _, inventoryType := range data.Get("types").(*schema.Set).List() {
+		types = append(types, inventoryType.(string))
+	}
+	sort.Strings(types)
+
+	items, err := keycloakClient.GetRealmInventory(ctx, realmId, types...)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// client roles are imported with the client id of their client, which is only known when clients are listed as well
+	clientIds := map[string]string{}
+	for _, item := range items {
+		if item.Type == keycloak.RealmInventoryClient {
+			clientIds[item.Id] = item.Name
+		}
+	}
+
+	var entries []interface{}
+	for _, item := range items {
+		entries = append(entries, map[string]interface{}{
+			"type":        item.Type,
+			"id":          item.Id,
+			"name":        item.Name,
+			"parent_id":   item.ParentId,
+			"provider_id": item.ProviderId,
+			"import_id":   realmInventoryImportId(realmId, item, clientIds),
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/inventory/%s", realmId, strings.Join(types, ",")))
+	data.Set("entries", entries)
+
+	return nil
+}
+
+// realmInventoryImportId returns the id that the resource for an object of a realm inventory can be imported with
+func realmInventoryImportId(realmId string, item *keycloak.RealmInventoryItem, clientIds map[string]string) string {
+	switch item.Type {
+	case keycloak.RealmInventoryClient, keycloak.RealmInventoryClientScope, keycloak.RealmInventoryIdentityProvider:
+		return fmt.Sprintf("%s/%s", realmId, item.Name)
+	case keycloak.RealmInventoryRole:
+		if item.ParentId == "" {
+			return fmt.Sprintf("%s/%s", realmId, item.Name)
+		}
+
+		clientKey, ok := clientIds[item.ParentId]
+		if !ok {
+			clientKey = item.ParentId
+		}
+
+		return fmt.Sprintf("%s/client/%s/role/%s", realmId, clientKey, item.Name)
+	case keycloak.RealmInventoryGroup:
+		// paths start with a slash
+		return realmId + "/" + item.Name
+	case keycloak.RealmInventoryComponent:
+		if item.ParentId != "" {
+			return fmt.Sprintf("%s/%s/%s", realmId, item.ParentId, item.Id)
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", realmId, item.Id)
+}
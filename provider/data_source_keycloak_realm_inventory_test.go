@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccKeycloakDataSourceRealmInventory_basic(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testDataSourceKeycloakRealmInventory_basic(realmName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("data.keycloak_realm_inventory.all", "entries.*", map[string]string{
+						"type":        "client",
+						"name":        "my-app",
+						"provider_id": "openid-connect",
+						"import_id":   fmt.Sprintf("%s/my-app", realmName),
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.keycloak_realm_inventory.all", "entries.*", map[string]string{
+						"type":      "role",
+						"name":      "admin",
+						"import_id": fmt.Sprintf("%s/client/my-app/role/admin", realmName),
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.keycloak_realm_inventory.all", "entries.*", map[string]string{
+						"type":      "group",
+						"name":      "/parent/child",
+						"import_id": fmt.Sprintf("%s//parent/child", realmName),
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.keycloak_realm_inventory.all", "entries.*", map[string]string{
+						"type": "authentication_flow",
+						"name": "browser",
+					}),
+
+					resource.TestCheckResourceAttr("data.keycloak_realm_inventory.groups", "entries.#", "2"),
+					resource.TestCheckResourceAttrPair("data.keycloak_realm_inventory.groups", "entries.0.id", "keycloak_group.parent", "id"),
+					resource.TestCheckResourceAttrPair("data.keycloak_realm_inventory.groups", "entries.1.id", "keycloak_group.child", "id"),
+					resource.TestCheckResourceAttrPair("data.keycloak_realm_inventory.groups", "entries.1.parent_id", "keycloak_group.parent", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testDataSourceKeycloakRealmInventory_basic(realm string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_openid_client" "client" {
+	realm_id    = keycloak_realm.realm.id
+	client_id   = "my-app"
+	access_type = "PUBLIC"
+}
+
+resource "keycloak_role" "client_role" {
+	realm_id  = keycloak_realm.realm.id
+	client_id = keycloak_openid_client.client.id
+	name      = "admin"
+}
+
+resource "keycloak_group" "parent" {
+	realm_id = keycloak_realm.realm.id
+	name     = "parent"
+}
+
+resource "keycloak_group" "child" {
+	realm_id  = keycloak_realm.realm.id
+	parent_id = keycloak_group.parent.id
+	name      = "child"
+}
+
+data "keycloak_realm_inventory" "all" {
+	realm_id = keycloak_realm.realm.id
+
+	depends_on = [
+		keycloak_role.client_role,
+		keycloak_group.child,
+	]
+}
+
+data "keycloak_realm_inventory" "groups" {
+	realm_id = keycloak_realm.realm.id
+	types    = ["group"]
+
+	depends_on = [
+		keycloak_group.child,
+	]
+}
+	`, realm)
+}
+
+func TestKeycloakDataSourceRealmInventory_importIdsAreUnique(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	// roles of different clients can have the same name
+	for _, clientId := range []string{"first-app", "second-app"} {
+		client := testResourceApply(t, "keycloak_openid_client", nil, map[string]interface{}{
+			"realm_id":    testAccRealm.Realm,
+			"client_id":   acctest.RandomWithPrefix(clientId),
+			"access_type": "PUBLIC",
+		})
+		defer testResourceDestroy(t, "keycloak_openid_client", client)
+
+		role := testResourceApply(t, "keycloak_role", nil, map[string]interface{}{
+			"realm_id":  testAccRealm.Realm,
+			"client_id": client.ID,
+			"name":      "admin",
+		})
+		defer testResourceDestroy(t, "keycloak_role", role)
+	}
+
+	inventory := testDataSourceRead(t, "keycloak_realm_inventory", map[string]interface{}{
+		"realm_id": testAccRealm.Realm,
+		"types":    testStringSet("client", "role"),
+	})
+
+	importIds := map[string]bool{}
+	adminRoles := 0
+	entries, _ := strconv.Atoi(inventory.Attributes["entries.#"])
+	for i := 0; i < entries; i++ {
+		importId := inventory.Attributes[fmt.Sprintf("entries.%d.import_id", i)]
+		if importIds[importId] {
+			t.Fatalf("expected every entry to have a different import_id, %s is repeated", importId)
+		}
+		importIds[importId] = true
+
+		if inventory.Attributes[fmt.Sprintf("entries.%d.type", i)] == "role" && inventory.Attributes[fmt.Sprintf("entries.%d.name", i)] == "admin" {
+			adminRoles++
+		}
+	}
+
+	if adminRoles != 2 {
+		t.Fatalf("expected the admin roles of both clients to be listed, got %d", adminRoles)
+	}
+}
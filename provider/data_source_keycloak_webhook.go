@@ -12,26 +12,26 @@ func dataSourceKeycloakWebhook() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceKeycloakWebhookRead,
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
 			"realm": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"enabled": {
-				Type: schema.TypeBool,
-				Optional: true,
+				Type:     schema.TypeBool,
+				Computed: true,
 			},
 			"url": {
-				Type: schema.TypeString,
-				Required: true,
-			},
-			"secret": {
-				Type: schema.TypeString,
-				Required: true,
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"event_types": {
 				Type:     schema.TypeSet,
 				Elem:     &schema.Schema{Type: schema.TypeString},
-				Required: true,
+				Computed: true,
 			},
 		},
 	}
@@ -48,6 +48,7 @@ func dataSourceKeycloakWebhookRead(ctx context.Context, data *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 
+	webhook.RealmName = realmName
 	mapFromWebhookToData(data, webhook)
 
 	return nil
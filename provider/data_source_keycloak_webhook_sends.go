@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func dataSourceKeycloakWebhookSends() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKeycloakWebhookSendsRead,
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"webhook_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"max_results": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      25,
+				ValidateFunc: validation.IntBetween(1, 1000),
+				Description:  "The number of recent delivery attempts to return.",
+			},
+			"sends": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"event_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The HTTP status returned by the webhook endpoint.",
+						},
+						"retries": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"sent_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKeycloakWebhookSendsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmName := data.Get("realm").(string)
+	webhookId := data.Get("webhook_id").(string)
+
+	sends, err := keycloakClient.GetWebhookSends(ctx, realmName, webhookId, data.Get("max_results").(int))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var sendsData []interface{}
+	for _, send := range sends {
+		sendsData = append(sendsData, map[string]interface{}{
+			"id":         send.Id,
+			"event_type": send.EventType,
+			"event_id":   send.EventId,
+			"status":     send.Status,
+			"retries":    send.Retries,
+			"sent_at":    string(send.SentAt),
+		})
+	}
+
+	data.SetId(fmt.Sprintf("%s/%s/sends", realmName, webhookId))
+	data.Set("sends", sendsData)
+
+	return nil
+}
@@ -0,0 +1,499 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateConfiguration reads a realm and returns Terraform configuration that manages it, keyed by file name. Every
+// resource comes with an import block, so an existing realm can be adopted with a plan and an apply instead of writing
+// and importing every resource by hand.
+//
+// Attribute values are produced by the same functions that resources use to read their state, so the configuration
+// matches what a plan expects. Attributes that are left at their default are omitted, objects are referred to by their
+// resource instead of their id, and secrets are turned into sensitive variables. Objects that Keycloak creates for
+// every realm, such as the built-in clients, roles, client scopes and authentication flows, are not generated, and
+// are looked up with data sources when a generated resource refers to them.
+func GenerateConfiguration(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId string) (map[string][]byte, error) {
+	generator := &configGenerator{
+		ctx:            ctx,
+		keycloakClient: keycloakClient,
+		realmId:        realmId,
+		provider:       KeycloakProvider(keycloakClient),
+		names:          map[string]bool{},
+		ids:            map[string]hcl.Traversal{},
+		flowAliases:    map[string]hcl.Traversal{},
+		builtInClients: map[string]*keycloak.OpenidClient{},
+		builtInRoles:   map[string]*keycloak.Role{},
+		dataSources:    map[string]hcl.Traversal{},
+		files:          map[string]*hclwrite.File{},
+	}
+
+	collectors := []func() error{
+		generator.collectRealm,
+		generator.collectClientScopes,
+		generator.collectClients,
+		generator.collectRoles,
+		generator.collectGroups,
+		generator.collectIdentityProviders,
+		generator.collectAuthenticationFlows,
+		generator.collectLdapUserFederations,
+	}
+
+	for _, collect := range collectors {
+		if err := collect(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, resource := range generator.resources {
+		if err := generator.writeResource(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	files := map[string][]byte{}
+	for name, file := range generator.files {
+		files[name] = hclwrite.Format(file.Bytes())
+	}
+
+	return files, nil
+}
+
+type generatedResource struct {
+	file         string
+	resourceType string
+	name         string
+	importId     string
+	data         *schema.ResourceData
+	dependsOn    []hcl.Traversal
+}
+
+func (resource *generatedResource) traversal(attribute string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: resource.resourceType},
+		hcl.TraverseAttr{Name: resource.name},
+		hcl.TraverseAttr{Name: attribute},
+	}
+}
+
+type configGenerator struct {
+	ctx            context.Context
+	keycloakClient *keycloak.KeycloakClient
+	realmId        string
+	provider       *schema.Provider
+
+	resources []*generatedResource
+	// resource names that are taken, per resource type or data source type
+	names map[string]bool
+	// the generated resources that Keycloak objects can be referred to with, by the id of the object
+	ids map[string]hcl.Traversal
+	// the generated flows and subflows, by their alias
+	flowAliases map[string]hcl.Traversal
+	realm       *generatedResource
+	clients     []*keycloak.OpenidClient
+
+	// built-in objects are looked up with data sources when they are referred to
+	builtInClients map[string]*keycloak.OpenidClient
+	builtInRoles   map[string]*keycloak.Role
+	dataSources    map[string]hcl.Traversal
+
+	files map[string]*hclwrite.File
+}
+
+var nonIdentifierCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resourceName turns a label, like the client id of a client, into a resource name that is unique for the type
+func (generator *configGenerator) resourceName(kind, label string) string {
+	name := strings.Trim(nonIdentifierCharacters.ReplaceAllString(strings.ToLower(label), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		prefix := strings.TrimPrefix(kind[strings.LastIndex(kind, ".")+1:], "keycloak_")
+		name = strings.Trim(prefix+"_"+name, "_")
+	}
+
+	unique := name
+	for i := 2; generator.names[kind+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	generator.names[kind+"."+unique] = true
+
+	return unique
+}
+
+// add generates a resource of the given type. setData fills in its attributes, the same way reading the resource does.
+func (generator *configGenerator) add(file, resourceType, label, importId string, setData func(data *schema.ResourceData) error) (*generatedResource, error) {
+	resourceSchema, ok := generator.provider.ResourcesMap[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource type %s", resourceType)
+	}
+
+	data := resourceSchema.Data(nil)
+	if err := setData(data); err != nil {
+		return nil, err
+	}
+
+	resource := &generatedResource{
+		file:         file,
+		resourceType: resourceType,
+		name:         generator.resourceName(resourceType, label),
+		importId:     importId,
+		data:         data,
+	}
+	generator.resources = append(generator.resources, resource)
+
+	return resource, nil
+}
+
+// addReferable generates a resource that other resources refer to by its id. Objects that are identified by a name,
+// such as identity providers, are added with add instead, since any string that happens to match the name would be
+// turned into a reference.
+func (generator *configGenerator) addReferable(file, resourceType, label, importId string, setData func(data *schema.ResourceData) error) (*generatedResource, error) {
+	resource, err := generator.add(file, resourceType, label, importId, setData)
+	if err != nil {
+		return nil, err
+	}
+
+	generator.ids[resource.data.Id()] = resource.traversal("id")
+
+	return resource, nil
+}
+
+func (generator *configGenerator) file(name string) *hclwrite.File {
+	file, ok := generator.files[name]
+	if !ok {
+		file = hclwrite.NewEmptyFile()
+		generator.files[name] = file
+	}
+
+	return file
+}
+
+// comment notes something that couldn't be generated at the top of a file
+func (generator *configGenerator) comment(file, text string) {
+	generator.file(file).Body().AppendUnstructuredTokens(hclwrite.Tokens{
+		{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")},
+	})
+}
+
+func (generator *configGenerator) writeResource(resource *generatedResource) error {
+	body := generator.file(resource.file).Body()
+	if len(body.Blocks()) != 0 {
+		body.AppendNewline()
+	}
+
+	block := body.AppendNewBlock("resource", []string{resource.resourceType, resource.name})
+
+	// optional attributes that reading the resource doesn't set are left out, instead of being written with their zero
+	// value. Required attributes are always written.
+	values := map[string]interface{}{}
+	for key, attributeSchema := range generator.provider.ResourcesMap[resource.resourceType].Schema {
+		if attributeSchema.Required {
+			values[key] = resource.data.Get(key)
+		}
+	}
+	for key := range resource.data.State().Attributes {
+		key = strings.SplitN(key, ".", 2)[0]
+		if _, ok := generator.provider.ResourcesMap[resource.resourceType].Schema[key]; ok {
+			values[key] = resource.data.Get(key)
+		}
+	}
+
+	err := generator.writeBody(block.Body(), resource, generator.provider.ResourcesMap[resource.resourceType].Schema, values, resource.name)
+	if err != nil {
+		return err
+	}
+
+	if len(resource.dependsOn) != 0 {
+		var dependencies []hclwrite.Tokens
+		for _, dependency := range resource.dependsOn {
+			dependencies = append(dependencies, hclwrite.TokensForTraversal(dependency[:2]))
+		}
+
+		block.Body().AppendNewline()
+		block.Body().SetAttributeRaw("depends_on", hclwrite.TokensForTuple(dependencies))
+	}
+
+	body.AppendNewline()
+	importBlock := body.AppendNewBlock("import", nil)
+	importBlock.Body().SetAttributeTraversal("to", resource.traversal("id")[:2])
+	importBlock.Body().SetAttributeValue("id", cty.StringVal(resource.importId))
+
+	return nil
+}
+
+// Attributes that hold the name of the realm, which are written as a reference to the realm resource
+var realmAttributes = map[string]bool{
+	"realm_id": true,
+	"realm":    true,
+}
+
+// Attributes that hold the alias of a flow, which are written as a reference to the flow or subflow resource
+var flowAliasAttributes = map[string]bool{
+	"parent_flow_alias":             true,
+	"first_broker_login_flow_alias": true,
+	"post_broker_login_flow_alias":  true,
+}
+
+// writeBody writes the attributes and nested blocks of a schema. The realm comes first, then the required attributes,
+// the optional attributes that aren't set to their default, and the nested blocks, each in alphabetical order.
+func (generator *configGenerator) writeBody(body *hclwrite.Body, resource *generatedResource, schemaMap map[string]*schema.Schema, values map[string]interface{}, path string) error {
+	var realmKeys, requiredKeys, optionalKeys, blockKeys []string
+	for key, attributeSchema := range schemaMap {
+		if !attributeSchema.Required && !attributeSchema.Optional {
+			continue
+		}
+
+		if _, ok := values[key]; !ok {
+			continue
+		}
+
+		if _, ok := attributeSchema.Elem.(*schema.Resource); ok {
+			blockKeys = append(blockKeys, key)
+		} else if realmAttributes[key] && attributeSchema.Required {
+			realmKeys = append(realmKeys, key)
+		} else if attributeSchema.Required {
+			requiredKeys = append(requiredKeys, key)
+		} else if !isDefaultValue(attributeSchema, values[key]) {
+			optionalKeys = append(optionalKeys, key)
+		}
+	}
+	sort.Strings(requiredKeys)
+	sort.Strings(optionalKeys)
+	sort.Strings(blockKeys)
+
+	for _, key := range append(append(realmKeys, requiredKeys...), optionalKeys...) {
+		tokens, err := generator.valueTokens(resource, schemaMap[key], key, values[key], path+"_"+key)
+		if err != nil {
+			return err
+		}
+
+		body.SetAttributeRaw(key, tokens)
+	}
+
+	for _, key := range blockKeys {
+		elemSchema := schemaMap[key].Elem.(*schema.Resource).Schema
+
+		var elements []interface{}
+		switch value := values[key].(type) {
+		case []interface{}:
+			elements = value
+		case *schema.Set:
+			elements = value.List()
+		}
+
+		for _, element := range elements {
+			elementValues, ok := element.(map[string]interface{})
+			if !ok || isDefaultBlock(elemSchema, elementValues) {
+				continue
+			}
+
+			block := body.AppendNewBlock(key, nil)
+			if err := generator.writeBody(block.Body(), resource, elemSchema, elementValues, path+"_"+key); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDefaultBlock reports whether every attribute of a nested block is set to its default, so it can be left out
+func isDefaultBlock(schemaMap map[string]*schema.Schema, values map[string]interface{}) bool {
+	for key, attributeSchema := range schemaMap {
+		if (attributeSchema.Required || attributeSchema.Optional) && !isDefaultValue(attributeSchema, values[key]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDefaultValue(attributeSchema *schema.Schema, value interface{}) bool {
+	if attributeSchema.Default != nil {
+		return reflect.DeepEqual(attributeSchema.Default, value)
+	}
+
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	case *schema.Set:
+		return v.Len() == 0
+	}
+
+	return false
+}
+
+func (generator *configGenerator) valueTokens(resource *generatedResource, attributeSchema *schema.Schema, key string, value interface{}, path string) (hclwrite.Tokens, error) {
+	if attributeSchema.Sensitive && attributeSchema.Type == schema.TypeString {
+		return hclwrite.TokensForTraversal(generator.variable(path)), nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return generator.stringTokens(resource, key, v), nil
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v))), nil
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v)), nil
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v)), nil
+	case []interface{}:
+		return generator.listTokens(resource, key, v, false), nil
+	case *schema.Set:
+		return generator.listTokens(resource, key, v.List(), true), nil
+	case map[string]interface{}:
+		if len(v) == 0 {
+			return hclwrite.TokensForValue(cty.MapValEmpty(cty.String)), nil
+		}
+
+		elements := map[string]cty.Value{}
+		for k, element := range v {
+			elements[k] = cty.StringVal(fmt.Sprint(element))
+		}
+
+		return hclwrite.TokensForValue(cty.MapVal(elements)), nil
+	}
+
+	return nil, fmt.Errorf("attribute %s of %s.%s has an unsupported type %T", key, resource.resourceType, resource.name, value)
+}
+
+func (generator *configGenerator) listTokens(resource *generatedResource, key string, values []interface{}, sorted bool) hclwrite.Tokens {
+	var elements []hclwrite.Tokens
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			elements = append(elements, generator.stringTokens(resource, key, v))
+		case int:
+			elements = append(elements, hclwrite.TokensForValue(cty.NumberIntVal(int64(v))))
+		case bool:
+			elements = append(elements, hclwrite.TokensForValue(cty.BoolVal(v)))
+		}
+	}
+
+	if sorted {
+		sort.Slice(elements, func(i, j int) bool {
+			return string(elements[i].Bytes()) < string(elements[j].Bytes())
+		})
+	}
+
+	return hclwrite.TokensForTuple(elements)
+}
+
+// stringTokens writes a string, or a reference to the resource or data source of the object that it identifies
+func (generator *configGenerator) stringTokens(resource *generatedResource, key, value string) hclwrite.Tokens {
+	// the realm is created before everything else, so it doesn't refer to anything
+	if resource.resourceType != "keycloak_realm" {
+		if realmAttributes[key] && value == generator.realmId && generator.realm != nil {
+			return hclwrite.TokensForTraversal(generator.realm.traversal("id"))
+		}
+
+		if traversal, ok := generator.flowAliases[value]; ok && flowAliasAttributes[key] {
+			return hclwrite.TokensForTraversal(traversal)
+		}
+
+		if traversal, ok := generator.ids[value]; ok {
+			return hclwrite.TokensForTraversal(traversal)
+		}
+
+		if traversal, ok := generator.builtInReference(value); ok {
+			return hclwrite.TokensForTraversal(traversal)
+		}
+	}
+
+	return hclwrite.TokensForValue(cty.StringVal(value))
+}
+
+// variable declares a sensitive variable for a secret, which Keycloak either doesn't return or masks
+func (generator *configGenerator) variable(path string) hcl.Traversal {
+	name := generator.resourceName("variable", path)
+
+	body := generator.file("variables.tf").Body()
+	if len(body.Blocks()) != 0 {
+		body.AppendNewline()
+	}
+
+	block := body.AppendNewBlock("variable", []string{name})
+	block.Body().SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	block.Body().SetAttributeValue("sensitive", cty.True)
+
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: name},
+	}
+}
+
+func (generator *configGenerator) builtInReference(id string) (hcl.Traversal, bool) {
+	if client, ok := generator.builtInClients[id]; ok {
+		return generator.dataSource("keycloak_openid_client", client.ClientId, func(body *hclwrite.Body) {
+			body.SetAttributeRaw("realm_id", hclwrite.TokensForTraversal(generator.realm.traversal("id")))
+			body.SetAttributeValue("client_id", cty.StringVal(client.ClientId))
+		}), true
+	}
+
+	if role, ok := generator.builtInRoles[id]; ok {
+		label := role.Name
+		if client, ok := generator.builtInClients[role.ClientId]; ok {
+			label = client.ClientId + "_" + role.Name
+		}
+
+		return generator.dataSource("keycloak_role", label, func(body *hclwrite.Body) {
+			body.SetAttributeRaw("realm_id", hclwrite.TokensForTraversal(generator.realm.traversal("id")))
+			if role.ClientRole {
+				clientReference, _ := generator.builtInReference(role.ClientId)
+				body.SetAttributeRaw("client_id", hclwrite.TokensForTraversal(clientReference))
+			}
+			body.SetAttributeValue("name", cty.StringVal(role.Name))
+		}), true
+	}
+
+	return nil, false
+}
+
+// dataSource declares a data source the first time it's referred to
+func (generator *configGenerator) dataSource(dataSourceType, label string, writeBody func(body *hclwrite.Body)) hcl.Traversal {
+	key := dataSourceType + "/" + label
+	if traversal, ok := generator.dataSources[key]; ok {
+		return traversal
+	}
+
+	name := generator.resourceName("data."+dataSourceType, label)
+	traversal := hcl.Traversal{
+		hcl.TraverseRoot{Name: "data"},
+		hcl.TraverseAttr{Name: dataSourceType},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: "id"},
+	}
+	generator.dataSources[key] = traversal
+
+	body := generator.file("data.tf").Body()
+	if len(body.Blocks()) != 0 {
+		body.AppendNewline()
+	}
+
+	writeBody(body.AppendNewBlock("data", []string{dataSourceType, name}).Body())
+
+	return traversal
+}
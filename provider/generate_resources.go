@@ -0,0 +1,573 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+// Clients, realm roles and client scopes that Keycloak creates for every realm
+var (
+	builtInClientIds = map[string]bool{
+		"account":                true,
+		"account-console":        true,
+		"admin-cli":              true,
+		"broker":                 true,
+		"realm-management":       true,
+		"security-admin-console": true,
+	}
+	builtInRealmRoleNames = map[string]bool{
+		"offline_access":    true,
+		"uma_authorization": true,
+	}
+	builtInClientScopeNames = map[string]bool{
+		"acr":               true,
+		"address":           true,
+		"basic":             true,
+		"email":             true,
+		"microprofile-jwt":  true,
+		"offline_access":    true,
+		"organization":      true,
+		"phone":             true,
+		"profile":           true,
+		"role_list":         true,
+		"roles":             true,
+		"saml_organization": true,
+		"service_account":   true,
+		"web-origins":       true,
+	}
+)
+
+func (generator *configGenerator) isBuiltInClient(client *keycloak.OpenidClient) bool {
+	// the master realm has a client for every other realm
+	return builtInClientIds[client.ClientId] || (generator.realmId == "master" && strings.HasSuffix(client.ClientId, "-realm"))
+}
+
+func (generator *configGenerator) isBuiltInRealmRole(role *keycloak.Role) bool {
+	if builtInRealmRoleNames[role.Name] || role.Name == "default-roles-"+strings.ToLower(generator.realmId) {
+		return true
+	}
+
+	return generator.realmId == "master" && (role.Name == "admin" || role.Name == "create-realm")
+}
+
+func (generator *configGenerator) collectRealm() error {
+	realm, err := generator.keycloakClient.GetRealm(generator.ctx, generator.realmId)
+	if err != nil {
+		return err
+	}
+
+	generator.realm, err = generator.add("realm.tf", "keycloak_realm", realm.Realm, realm.Realm, func(data *schema.ResourceData) error {
+		setRealmData(data, realm)
+		return nil
+	})
+
+	return err
+}
+
+func (generator *configGenerator) collectClientScopes() error {
+	openidClientScopes, err := generator.keycloakClient.ListOpenidClientScopesWithFilter(generator.ctx, generator.realmId, func(scope *keycloak.OpenidClientScope) bool {
+		return !builtInClientScopeNames[scope.Name]
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(openidClientScopes, func(i, j int) bool {
+		return openidClientScopes[i].Name < openidClientScopes[j].Name
+	})
+
+	for _, clientScope := range openidClientScopes {
+		clientScope := clientScope
+
+		resource, err := generator.addReferable("client_scopes.tf", "keycloak_openid_client_scope", clientScope.Name, generator.realmId+"/"+clientScope.Id, func(data *schema.ResourceData) error {
+			setOpenidClientScopeData(data, clientScope)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := generator.collectProtocolMappers("client_scopes.tf", resource, "", clientScope.Id); err != nil {
+			return err
+		}
+	}
+
+	samlClientScopes, err := generator.keycloakClient.ListSamlClientScopesWithFilter(generator.ctx, generator.realmId, func(scope *keycloak.SamlClientScope) bool {
+		return !builtInClientScopeNames[scope.Name]
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(samlClientScopes, func(i, j int) bool {
+		return samlClientScopes[i].Name < samlClientScopes[j].Name
+	})
+
+	for _, clientScope := range samlClientScopes {
+		clientScope := clientScope
+		clientScope.RealmId = generator.realmId
+
+		resource, err := generator.addReferable("client_scopes.tf", "keycloak_saml_client_scope", clientScope.Name, generator.realmId+"/"+clientScope.Id, func(data *schema.ResourceData) error {
+			setSamlClientScopeData(data, clientScope)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := generator.collectProtocolMappers("client_scopes.tf", resource, "", clientScope.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectClients() error {
+	clients, err := generator.keycloakClient.GetOpenidClients(generator.ctx, generator.realmId, false)
+	if err != nil {
+		return err
+	}
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].ClientId < clients[j].ClientId
+	})
+
+	for _, client := range clients {
+		if generator.isBuiltInClient(client) {
+			generator.builtInClients[client.Id] = client
+			continue
+		}
+
+		var resource *generatedResource
+		switch client.Protocol {
+		case "openid-connect":
+			openidClient, err := generator.keycloakClient.GetOpenidClient(generator.ctx, generator.realmId, client.Id)
+			if err != nil {
+				return err
+			}
+
+			resource, err = generator.addReferable("clients.tf", "keycloak_openid_client", client.ClientId, generator.realmId+"/"+client.Id, func(data *schema.ResourceData) error {
+				return setOpenidClientData(generator.ctx, generator.keycloakClient, data, openidClient)
+			})
+			if err != nil {
+				return err
+			}
+		case "saml":
+			samlClient, err := generator.keycloakClient.GetSamlClient(generator.ctx, generator.realmId, client.Id)
+			if err != nil {
+				return err
+			}
+
+			resource, err = generator.addReferable("clients.tf", "keycloak_saml_client", client.ClientId, generator.realmId+"/"+client.Id, func(data *schema.ResourceData) error {
+				return mapToDataFromSamlClient(generator.ctx, data, samlClient)
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			generator.comment("clients.tf", fmt.Sprintf("client %s uses the %s protocol, which isn't supported", client.ClientId, client.Protocol))
+			continue
+		}
+
+		if err := generator.collectProtocolMappers("clients.tf", resource, client.Id, ""); err != nil {
+			return err
+		}
+	}
+
+	generator.clients = clients
+
+	return nil
+}
+
+// collectProtocolMappers generates the protocol mappers of a client, or of a client scope when clientScopeId is set
+func (generator *configGenerator) collectProtocolMappers(file string, parent *generatedResource, clientId, clientScopeId string) error {
+	mappers, err := generator.keycloakClient.GetGenericProtocolMappersOf(generator.ctx, generator.realmId, clientId, clientScopeId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(mappers, func(i, j int) bool {
+		return mappers[i].Name < mappers[j].Name
+	})
+
+	importPrefix := generator.realmId + "/client/" + clientId
+	if clientScopeId != "" {
+		importPrefix = generator.realmId + "/client-scope/" + clientScopeId
+	}
+
+	for _, mapper := range mappers {
+		mapper := mapper
+
+		_, err := generator.add(file, "keycloak_generic_protocol_mapper", parent.name+"_"+mapper.Name, importPrefix+"/"+mapper.Id, func(data *schema.ResourceData) error {
+			mapFromGenericProtocolMapperToData(data, mapper)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectRoles() error {
+	realmRoles, err := generator.keycloakClient.GetRealmRoles(generator.ctx, generator.realmId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(realmRoles, func(i, j int) bool {
+		return realmRoles[i].Name < realmRoles[j].Name
+	})
+
+	clientRoles, err := generator.keycloakClient.GetClientRoles(generator.ctx, generator.realmId, generator.clients)
+	if err != nil {
+		return err
+	}
+
+	clientIds := map[string]string{}
+	for _, client := range generator.clients {
+		clientIds[client.Id] = client.ClientId
+	}
+	sort.Slice(clientRoles, func(i, j int) bool {
+		if clientIds[clientRoles[i].ClientId] != clientIds[clientRoles[j].ClientId] {
+			return clientIds[clientRoles[i].ClientId] < clientIds[clientRoles[j].ClientId]
+		}
+
+		return clientRoles[i].Name < clientRoles[j].Name
+	})
+
+	// every role is registered before composites are written, since composites can refer to roles of any client
+	var roles []*keycloak.Role
+	for _, role := range realmRoles {
+		if generator.isBuiltInRealmRole(role) {
+			generator.builtInRoles[role.Id] = role
+			continue
+		}
+
+		roles = append(roles, role)
+	}
+	for _, role := range clientRoles {
+		role.ClientRole = true
+		if _, ok := generator.builtInClients[role.ClientId]; ok {
+			generator.builtInRoles[role.Id] = role
+			continue
+		}
+
+		roles = append(roles, role)
+	}
+
+	for _, role := range roles {
+		role := role
+
+		label := role.Name
+		if role.ClientRole {
+			label = clientIds[role.ClientId] + "_" + role.Name
+		}
+
+		var composites []*keycloak.Role
+		if role.Composite {
+			composites, err = generator.keycloakClient.GetRoleComposites(generator.ctx, role)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := generator.addReferable("roles.tf", "keycloak_role", label, generator.realmId+"/"+role.Id, func(data *schema.ResourceData) error {
+			mapFromRoleToData(data, role)
+
+			var compositeRoles []string
+			for _, composite := range composites {
+				compositeRoles = append(compositeRoles, composite.Id)
+			}
+
+			return data.Set("composite_roles", compositeRoles)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectGroups() error {
+	// parents come before their subgroups, so subgroups can refer to them
+	groups, err := generator.keycloakClient.GetGroupSubtree(generator.ctx, generator.realmId, "", 0)
+	if err != nil {
+		return err
+	}
+
+	for _, group := range groups {
+		group := group
+
+		_, err := generator.addReferable("groups.tf", "keycloak_group", group.Path, generator.realmId+"/"+group.Id, func(data *schema.ResourceData) error {
+			mapFromGroupToData(data, group)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectIdentityProviders() error {
+	identityProviders, err := generator.keycloakClient.GetIdentityProviders(generator.ctx, generator.realmId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(identityProviders, func(i, j int) bool {
+		return identityProviders[i].Alias < identityProviders[j].Alias
+	})
+
+	for _, identityProvider := range identityProviders {
+		identityProvider := identityProvider
+
+		var resourceType string
+		var setData func(data *schema.ResourceData, identityProvider *keycloak.IdentityProvider) error
+		switch identityProvider.ProviderId {
+		case "oidc", "keycloak-oidc":
+			resourceType, setData = "keycloak_oidc_identity_provider", setOidcIdentityProviderData
+		case "saml":
+			resourceType, setData = "keycloak_saml_identity_provider", setSamlIdentityProviderData
+		case "google":
+			resourceType, setData = "keycloak_oidc_google_identity_provider", setOidcGoogleIdentityProviderData
+		default:
+			generator.comment("identity_providers.tf", fmt.Sprintf("identity provider %s uses the %s provider, which isn't supported", identityProvider.Alias, identityProvider.ProviderId))
+			continue
+		}
+
+		// identity providers are identified by their alias, so they're not referable
+		_, err := generator.add("identity_providers.tf", resourceType, identityProvider.Alias, generator.realmId+"/"+identityProvider.Alias, func(data *schema.ResourceData) error {
+			// reading an identity provider keeps the provider id from the configuration
+			if err := data.Set("provider_id", identityProvider.ProviderId); err != nil {
+				return err
+			}
+
+			return setData(data, identityProvider)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectAuthenticationFlows() error {
+	flows, err := generator.keycloakClient.ListAuthenticationFlows(generator.ctx, generator.realmId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(flows, func(i, j int) bool {
+		return flows[i].Alias < flows[j].Alias
+	})
+
+	for _, flow := range flows {
+		flow := flow
+		if flow.BuiltIn {
+			continue
+		}
+
+		resource, err := generator.addReferable("authentication.tf", "keycloak_authentication_flow", flow.Alias, generator.realmId+"/"+flow.Id, func(data *schema.ResourceData) error {
+			mapFromAuthenticationFlowToData(data, flow)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		generator.flowAliases[flow.Alias] = resource.traversal("alias")
+
+		if err := generator.collectAuthenticationExecutions(flow.Alias); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// collectAuthenticationExecutions generates the executions and subflows of a flow. Executions don't have a priority,
+// Keycloak adds them to the end of their flow, so every execution depends on the one before it.
+func (generator *configGenerator) collectAuthenticationExecutions(flowAlias string) error {
+	executions, err := generator.keycloakClient.ListAuthenticationExecutions(generator.ctx, generator.realmId, flowAlias)
+	if err != nil {
+		return err
+	}
+
+	// the alias of the flow that the executions on each level belong to
+	parentAliases := []string{flowAlias}
+	previous := map[string]*generatedResource{}
+
+	for _, execution := range executions {
+		if execution.Level >= len(parentAliases) {
+			return fmt.Errorf("execution %s of flow %s is on level %d, which has no parent flow", execution.Id, flowAlias, execution.Level)
+		}
+
+		parentAlias := parentAliases[execution.Level]
+		parentAliases = parentAliases[:execution.Level+1]
+
+		var resource *generatedResource
+		if execution.AuthenticationFlow {
+			subFlow, err := generator.keycloakClient.GetAuthenticationSubFlow(generator.ctx, generator.realmId, parentAlias, execution.FlowId)
+			if err != nil {
+				return err
+			}
+
+			resource, err = generator.addReferable("authentication.tf", "keycloak_authentication_subflow", subFlow.Alias, generator.realmId+"/"+parentAlias+"/"+subFlow.Id, func(data *schema.ResourceData) error {
+				mapFromAuthenticationSubFlowToData(data, subFlow)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			generator.flowAliases[subFlow.Alias] = resource.traversal("alias")
+			parentAliases = append(parentAliases, subFlow.Alias)
+		} else {
+			authenticationExecution, err := generator.keycloakClient.GetAuthenticationExecution(generator.ctx, generator.realmId, parentAlias, execution.Id)
+			if err != nil {
+				return err
+			}
+
+			resource, err = generator.addReferable("authentication.tf", "keycloak_authentication_execution", parentAlias+"_"+authenticationExecution.Authenticator, generator.realmId+"/"+parentAlias+"/"+authenticationExecution.Id, func(data *schema.ResourceData) error {
+				mapFromAuthenticationExecutionToData(data, authenticationExecution)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if sibling, ok := previous[parentAlias]; ok {
+			resource.dependsOn = append(resource.dependsOn, sibling.traversal("id"))
+		}
+		previous[parentAlias] = resource
+
+		if execution.AuthenticationConfig != "" {
+			config := &keycloak.AuthenticationExecutionConfig{
+				RealmId:     generator.realmId,
+				ExecutionId: execution.Id,
+				Id:          execution.AuthenticationConfig,
+			}
+			if err := generator.keycloakClient.GetAuthenticationExecutionConfig(generator.ctx, config); err != nil {
+				return err
+			}
+
+			_, err := generator.add("authentication.tf", "keycloak_authentication_execution_config", config.Alias, generator.realmId+"/"+execution.Id+"/"+config.Id, func(data *schema.ResourceData) error {
+				setAuthenticationExecutionConfigData(data, config)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (generator *configGenerator) collectLdapUserFederations() error {
+	ldapUserFederations, err := generator.keycloakClient.GetLdapUserFederations(generator.ctx, generator.realmId)
+	if err != nil {
+		return err
+	}
+	sort.Slice(ldapUserFederations, func(i, j int) bool {
+		return ldapUserFederations[i].Name < ldapUserFederations[j].Name
+	})
+
+	for _, ldapUserFederation := range ldapUserFederations {
+		ldapUserFederation := ldapUserFederation
+
+		resource, err := generator.addReferable("ldap.tf", "keycloak_ldap_user_federation", ldapUserFederation.Name, generator.realmId+"/"+ldapUserFederation.Id, func(data *schema.ResourceData) error {
+			setLdapUserFederationData(data, ldapUserFederation, generator.realmId)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := generator.collectLdapMappers(resource, ldapUserFederation.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type generatedLdapMapper struct {
+	resourceType string
+	id           string
+	name         string
+	setData      func(data *schema.ResourceData) error
+}
+
+func (generator *configGenerator) collectLdapMappers(parent *generatedResource, ldapUserFederationId string) error {
+	components, err := generator.keycloakClient.GetLdapUserFederationMappers(generator.ctx, generator.realmId, ldapUserFederationId)
+	if err != nil {
+		return err
+	}
+
+	var mappers []*generatedLdapMapper
+	for _, component := range *components {
+		switch mapper := component.(type) {
+		case *keycloak.LdapFullNameMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_full_name_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapFullNameMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapGroupMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_group_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				return setLdapGroupMapperData(generator.ctx, generator.keycloakClient, data, mapper)
+			}})
+		case *keycloak.LdapHardcodedAttributeMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_hardcoded_attribute_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapHardcodedAttributeMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapHardcodedGroupMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_hardcoded_group_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapHardcodedGroupMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapHardcodedRoleMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_hardcoded_role_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapHardcodedRoleMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapMsadLdsUserAccountControlMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_msad_lds_user_account_control_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapMsadLdsUserAccountControlMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapMsadUserAccountControlMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_msad_user_account_control_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapMsadUserAccountControlMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapRoleMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_role_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapRoleMapperData(data, mapper)
+				return nil
+			}})
+		case *keycloak.LdapUserAttributeMapper:
+			mappers = append(mappers, &generatedLdapMapper{"keycloak_ldap_user_attribute_mapper", mapper.Id, mapper.Name, func(data *schema.ResourceData) error {
+				setLdapUserAttributeMapperData(data, mapper)
+				return nil
+			}})
+		}
+	}
+	sort.Slice(mappers, func(i, j int) bool {
+		return mappers[i].name < mappers[j].name
+	})
+
+	for _, mapper := range mappers {
+		_, err := generator.add("ldap.tf", mapper.resourceType, parent.name+"_"+mapper.name, generator.realmId+"/"+ldapUserFederationId+"/"+mapper.id, mapper.setData)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
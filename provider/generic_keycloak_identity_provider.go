@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -174,6 +175,11 @@ func resourceKeycloakIdentityProviderDelete(ctx context.Context, data *schema.Re
 	realm := data.Get("realm").(string)
 	alias := data.Get("alias").(string)
 
+	err := keycloakClient.BackupIdentityProvider(ctx, realm, alias)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.FromErr(keycloakClient.DeleteIdentityProvider(ctx, realm, alias))
 }
 
@@ -191,7 +197,7 @@ func resourceKeycloakIdentityProviderImport(_ context.Context, d *schema.Resourc
 	return []*schema.ResourceData{d}, nil
 }
 
-func resourceKeycloakIdentityProviderCreate(getIdentityProviderFromData identityProviderDataGetterFunc, setDataFromIdentityProvider identityProviderDataSetterFunc) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceKeycloakIdentityProviderCreate(getIdentityProviderFromData identityProviderDataGetterFunc, setDataFromIdentityProvider identityProviderDataSetterFunc, attributePath func(field string) cty.Path) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 		keycloakClient := meta.(*keycloak.KeycloakClient)
 		identityProvider, err := getIdentityProviderFromData(data)
@@ -200,7 +206,7 @@ func resourceKeycloakIdentityProviderCreate(getIdentityProviderFromData identity
 		}
 
 		if err = keycloakClient.NewIdentityProvider(ctx, identityProvider); err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(err, attributePath)
 		}
 		if err = setDataFromIdentityProvider(data, identityProvider); err != nil {
 			return diag.FromErr(err)
@@ -223,7 +229,7 @@ func resourceKeycloakIdentityProviderRead(setDataFromIdentityProvider identityPr
 	}
 }
 
-func resourceKeycloakIdentityProviderUpdate(getIdentityProviderFromData identityProviderDataGetterFunc, setDataFromIdentityProvider identityProviderDataSetterFunc) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceKeycloakIdentityProviderUpdate(getIdentityProviderFromData identityProviderDataGetterFunc, setDataFromIdentityProvider identityProviderDataSetterFunc, attributePath func(field string) cty.Path) func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 		keycloakClient := meta.(*keycloak.KeycloakClient)
 		identityProvider, err := getIdentityProviderFromData(data)
@@ -231,10 +237,17 @@ func resourceKeycloakIdentityProviderUpdate(getIdentityProviderFromData identity
 			return diag.FromErr(err)
 		}
 
-		err = keycloakClient.UpdateIdentityProvider(ctx, identityProvider)
+		// the home IdP discovery domains are managed by keycloak_organization_identity_provider
+		currentIdentityProvider, err := keycloakClient.GetIdentityProvider(ctx, identityProvider.Realm, identityProvider.Alias)
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		identityProvider.KeepHomeIdpDiscoveryConfig(currentIdentityProvider)
+
+		err = keycloakClient.UpdateIdentityProvider(ctx, identityProvider)
+		if err != nil {
+			return apiErrorDiagnostics(err, attributePath)
+		}
 
 		return diag.FromErr(setDataFromIdentityProvider(data, identityProvider))
 	}
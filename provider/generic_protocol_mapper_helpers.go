@@ -4,28 +4,51 @@ import (
 	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
 	"strings"
 )
 
-func genericProtocolMapperImport(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+func genericProtocolMapperImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	// the client or client scope can be identified by its client id or name, which can contain slashes
 	parts := strings.Split(data.Id(), "/")
-	if len(parts) != 4 {
+	if len(parts) < 4 {
 		return nil, fmt.Errorf("invalid import. supported import formats: {{realmId}}/client/{{clientId}}/{{protocolMapperId}}, {{realmId}}/client-scope/{{clientScopeId}}/{{protocolMapperId}}")
 	}
 
+	realmId := parts[0]
 	parentResourceType := parts[1]
-	parentResourceId := parts[2]
-
-	data.Set("realm_id", parts[0])
-	data.SetId(parts[3])
+	parentResourceKey := strings.Join(parts[2:len(parts)-1], "/")
+	protocolMapperKey := parts[len(parts)-1]
 
+	var clientId, clientScopeId string
+	var err error
 	if parentResourceType == "client" {
-		data.Set("client_id", parentResourceId)
+		clientId, err = resolveClientImportKey(ctx, keycloakClient, realmId, parentResourceKey)
 	} else if parentResourceType == "client-scope" {
-		data.Set("client_scope_id", parentResourceId)
+		// protocol mappers can belong to openid and saml client scopes
+		clientScopeId, err = resolveClientScopeImportKey(ctx, keycloakClient, realmId, "", parentResourceKey)
 	} else {
 		return nil, fmt.Errorf("the associated parent resource must be either a client or a client-scope")
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := resolveProtocolMapperImportKey(ctx, keycloakClient, realmId, clientId, clientScopeId, protocolMapperKey)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Set("realm_id", realmId)
+	data.SetId(id)
+
+	if clientId != "" {
+		data.Set("client_id", clientId)
+	} else {
+		data.Set("client_scope_id", clientScopeId)
+	}
 
 	return []*schema.ResourceData{data}, nil
 }
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+// Importers accept natural keys, such as the client id of a client, the name of a role or the path of a group, wherever
+// they accept the id that Keycloak assigned to an object. Ids are tried first, so existing import ids keep working, and
+// keys that contain a slash, like client ids that are URLs, are never tried as an id.
+
+// splitRealmImportId splits an import id into the realm and the rest of the id, which can contain slashes
+func splitRealmImportId(importId string) (string, string, bool) {
+	parts := strings.SplitN(importId, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+
+	return parts[0], parts[1], true
+}
+
+// resolveClientImportKey returns the id of the client that key refers to, which is either its id or its client id
+func resolveClientImportKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, key string) (string, error) {
+	if !strings.Contains(key, "/") {
+		_, err := keycloakClient.GetGenericClient(ctx, realmId, key)
+		if err == nil {
+			return key, nil
+		}
+		if !keycloak.ErrorIs404(err) {
+			return "", err
+		}
+	}
+
+	client, err := keycloakClient.GetGenericClientByClientId(ctx, realmId, key)
+	if err != nil {
+		return "", err
+	}
+
+	return client.Id, nil
+}
+
+// resolveClientScopeImportKey returns the id of the client scope that key refers to, which is either its id or its name.
+// Only client scopes of the given protocol are considered, unless the protocol is empty.
+func resolveClientScopeImportKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, protocol, key string) (string, error) {
+	if !strings.Contains(key, "/") {
+		// openid and saml client scopes are read the same way
+		clientScope, err := keycloakClient.GetOpenidClientScope(ctx, realmId, key)
+		if err == nil && (protocol == "" || clientScope.Protocol == protocol) {
+			return key, nil
+		}
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return "", err
+		}
+	}
+
+	if protocol != "saml" {
+		openidClientScopes, err := keycloakClient.ListOpenidClientScopesWithFilter(ctx, realmId, keycloak.IncludeOpenidClientScopesMatchingNames([]string{key}))
+		if err != nil {
+			return "", err
+		}
+		if len(openidClientScopes) != 0 {
+			return openidClientScopes[0].Id, nil
+		}
+	}
+
+	if protocol != "openid-connect" {
+		samlClientScopes, err := keycloakClient.ListSamlClientScopesWithFilter(ctx, realmId, func(clientScope *keycloak.SamlClientScope) bool {
+			return clientScope.Name == key
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(samlClientScopes) != 0 {
+			return samlClientScopes[0].Id, nil
+		}
+	}
+
+	if protocol != "" {
+		return "", fmt.Errorf("%s client scope %s does not exist in realm %s", protocol, key, realmId)
+	}
+
+	return "", fmt.Errorf("client scope %s does not exist in realm %s", key, realmId)
+}
+
+// resolveRoleImportKey returns the id of the role that key refers to. key is either the id of a role, the name of a
+// realm role, or client/{{clientId}}/role/{{name}} for a client role, where the client is identified by its id or its
+// client id.
+func resolveRoleImportKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, key string) (string, error) {
+	if strings.HasPrefix(key, "client/") {
+		if i := strings.LastIndex(key, "/role/"); i > len("client/") {
+			clientId, err := resolveClientImportKey(ctx, keycloakClient, realmId, key[len("client/"):i])
+			if err != nil {
+				return "", err
+			}
+
+			role, err := keycloakClient.GetRoleByName(ctx, realmId, clientId, key[i+len("/role/"):])
+			if err != nil {
+				return "", err
+			}
+
+			return role.Id, nil
+		}
+	}
+
+	if !strings.Contains(key, "/") {
+		_, err := keycloakClient.GetRole(ctx, realmId, key)
+		if err == nil {
+			return key, nil
+		}
+		if !keycloak.ErrorIs404(err) {
+			return "", err
+		}
+	}
+
+	role, err := keycloakClient.GetRoleByName(ctx, realmId, "", key)
+	if err != nil {
+		return "", err
+	}
+
+	return role.Id, nil
+}
+
+// resolveGroupImportKey returns the id of the group that key refers to, which is either its id or its path, like
+// /parent/child. Paths start with a slash, so an import id with a path looks like my-realm//parent/child.
+func resolveGroupImportKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, key string) (string, error) {
+	if !strings.HasPrefix(key, "/") {
+		return key, nil
+	}
+
+	group, err := keycloakClient.GetGroupByPath(ctx, realmId, key)
+	if err != nil {
+		return "", err
+	}
+
+	return group.Id, nil
+}
+
+// resolveProtocolMapperImportKey returns the id of the protocol mapper of a client or client scope that key refers to,
+// which is either its id or its name
+func resolveProtocolMapperImportKey(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realmId, clientId, clientScopeId, key string) (string, error) {
+	mappers, err := keycloakClient.GetGenericProtocolMappersOf(ctx, realmId, clientId, clientScopeId)
+	if err != nil {
+		return "", err
+	}
+
+	for _, mapper := range mappers {
+		if mapper.Id == key {
+			return mapper.Id, nil
+		}
+	}
+
+	for _, mapper := range mappers {
+		if mapper.Name == key {
+			return mapper.Id, nil
+		}
+	}
+
+	return "", fmt.Errorf("protocol mapper %s does not exist", key)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestResolveClientScopeImportKeyOnlyFindsScopesOfTheProtocol(t *testing.T) {
+	openidClientScope := &keycloak.OpenidClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-acc")}
+	if err := keycloakClient.NewOpenidClientScope(testCtx, openidClientScope); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer keycloakClient.DeleteOpenidClientScope(testCtx, testAccRealm.Realm, openidClientScope.Id)
+
+	samlClientScope := &keycloak.SamlClientScope{RealmId: testAccRealm.Realm, Name: acctest.RandomWithPrefix("tf-acc")}
+	if err := keycloakClient.NewSamlClientScope(testCtx, samlClientScope); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer keycloakClient.DeleteSamlClientScope(testCtx, testAccRealm.Realm, samlClientScope.Id)
+
+	tests := []struct {
+		protocol string
+		key      string
+		id       string
+	}{
+		{"openid-connect", openidClientScope.Name, openidClientScope.Id},
+		{"openid-connect", openidClientScope.Id, openidClientScope.Id},
+		{"openid-connect", samlClientScope.Name, ""},
+		{"openid-connect", samlClientScope.Id, ""},
+		{"saml", samlClientScope.Name, samlClientScope.Id},
+		{"saml", samlClientScope.Id, samlClientScope.Id},
+		{"saml", openidClientScope.Name, ""},
+		{"", openidClientScope.Name, openidClientScope.Id},
+		{"", samlClientScope.Name, samlClientScope.Id},
+		{"", samlClientScope.Id, samlClientScope.Id},
+	}
+
+	for _, test := range tests {
+		id, err := resolveClientScopeImportKey(testCtx, keycloakClient, testAccRealm.Realm, test.protocol, test.key)
+		if test.id == "" {
+			if err == nil {
+				t.Errorf("expected %s not to be found as a %q client scope, got %s", test.key, test.protocol, id)
+			}
+			continue
+		}
+
+		if err != nil || id != test.id {
+			t.Errorf("expected %s to be found as a %q client scope with id %s, got %s, %v", test.key, test.protocol, test.id, id, err)
+		}
+	}
+}
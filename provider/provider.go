@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"io/ioutil"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
 )
@@ -14,12 +17,15 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 	provider := &schema.Provider{
 		DataSourcesMap: map[string]*schema.Resource{
 			"keycloak_group":                              dataSourceKeycloakGroup(),
+			"keycloak_groups":                             dataSourceKeycloakGroups(),
 			"keycloak_openid_client":                      dataSourceKeycloakOpenidClient(),
 			"keycloak_openid_client_authorization_policy": dataSourceKeycloakOpenidClientAuthorizationPolicy(),
 			"keycloak_openid_client_scope":                dataSourceKeycloakOpenidClientScope(),
 			"keycloak_openid_client_service_account_user": dataSourceKeycloakOpenidClientServiceAccountUser(),
 			"keycloak_realm":                              dataSourceKeycloakRealm(),
 			"keycloak_realm_keys":                         dataSourceKeycloakRealmKeys(),
+			"keycloak_realm_export":                       dataSourceKeycloakRealmExport(),
+			"keycloak_realm_inventory":                    dataSourceKeycloakRealmInventory(),
 			"keycloak_role":                               dataSourceKeycloakRole(),
 			"keycloak_user":                               dataSourceKeycloakUser(),
 			"keycloak_user_realm_roles":                   dataSourceKeycloakUserRealmRoles(),
@@ -30,7 +36,10 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_client_description_converter":       dataSourceKeycloakClientDescriptionConverter(),
 			"keycloak_organization":                       dataSourceKeycloakOrganization(),
 			"keycloak_organization_role":                  dataSourceKeycloakOrganizationRole(),
+			"keycloak_organization_invitations":           dataSourceKeycloakOrganizationInvitations(),
+			"keycloak_organizations":                      dataSourceKeycloakOrganizations(),
 			"keycloak_webhook":                       	   dataSourceKeycloakWebhook(),
+			"keycloak_webhook_sends":                      dataSourceKeycloakWebhookSends(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"keycloak_realm":                                             resourceKeycloakRealm(),
@@ -42,6 +51,10 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_realm_keystore_rsa":                                resourceKeycloakRealmKeystoreRsa(),
 			"keycloak_realm_keystore_rsa_generated":                      resourceKeycloakRealmKeystoreRsaGenerated(),
 			"keycloak_realm_user_profile":                                resourceKeycloakRealmUserProfile(),
+			"keycloak_realm_client_profiles":                             resourceKeycloakRealmClientProfiles(),
+			"keycloak_realm_client_policies":                             resourceKeycloakRealmClientPolicies(),
+			"keycloak_realm_localization":                                resourceKeycloakRealmLocalization(),
+			"keycloak_realm_partial_import":                              resourceKeycloakRealmPartialImport(),
 			"keycloak_required_action":                                   resourceKeycloakRequiredAction(),
 			"keycloak_group":                                             resourceKeycloakGroup(),
 			"keycloak_group_memberships":                                 resourceKeycloakGroupMemberships(),
@@ -121,11 +134,16 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 			"keycloak_authentication_bindings":                           resourceKeycloakAuthenticationBindings(),
 			"keycloak_organization":                                      resourceKeycloakOrganization(),
 			"keycloak_organization_role":                                 resourceKeycloakOrganizationRole(),
+			"keycloak_organization_member":                               resourceKeycloakOrganizationMember(),
+			"keycloak_organization_member_role":                          resourceKeycloakOrganizationMemberRole(),
+			"keycloak_organization_domain":                               resourceKeycloakOrganizationDomain(),
+			"keycloak_organization_identity_provider":                    resourceKeycloakOrganizationIdentityProvider(),
+			"keycloak_organization_invitation":                           resourceKeycloakOrganizationInvitation(),
 			"keycloak_webhook":                                      	  resourceKeycloakWebhook(),
 		},
 		Schema: map[string]*schema.Schema{
 			"client_id": {
-				Required:    true,
+				Optional:    true,
 				Type:        schema.TypeString,
 				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ID", nil),
 			},
@@ -197,6 +215,154 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 					Type: schema.TypeString,
 				},
 			},
+			"redacted_log_fields": {
+				Optional:    true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Additional JSON keys and form fields whose values are masked when requests and responses are logged.",
+			},
+			"har_record_file": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Description:   "Records every request sent to the Keycloak admin API, and its response, to this HTTP Archive (HAR) file. Bodies are redacted.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_HAR_RECORD_FILE", ""),
+				ConflictsWith: []string{"har_replay_file"},
+			},
+			"har_replay_file": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Description:   "Serves the responses recorded in this HTTP Archive (HAR) file instead of calling Keycloak.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_HAR_REPLAY_FILE", ""),
+				ConflictsWith: []string{"har_record_file"},
+			},
+			"access_token": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Description:   "An access token used to call the Keycloak API instead of logging in. The token is not renewed when it expires.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_ACCESS_TOKEN", ""),
+				ConflictsWith: []string{"access_token_command"},
+			},
+			"access_token_command": {
+				Optional:      true,
+				Type:          schema.TypeList,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				Description:   "A command, and its arguments, that prints an access token used to call the Keycloak API instead of logging in. The command is run again when the token expires.",
+				ConflictsWith: []string{"access_token"},
+			},
+			"client_assertion_private_key": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Sensitive:     true,
+				Description:   "PEM encoded RSA or EC private key used to sign a JWT client assertion, for clients using the `Signed JWT` client authenticator.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ASSERTION_PRIVATE_KEY", nil),
+				ConflictsWith: []string{"client_secret", "client_assertion_private_key_file"},
+			},
+			"client_assertion_private_key_file": {
+				Optional:      true,
+				Type:          schema.TypeString,
+				Description:   "Path to a file containing a PEM encoded RSA or EC private key used to sign a JWT client assertion, for clients using the `Signed JWT` client authenticator.",
+				DefaultFunc:   schema.EnvDefaultFunc("KEYCLOAK_CLIENT_ASSERTION_PRIVATE_KEY_FILE", nil),
+				ConflictsWith: []string{"client_secret", "client_assertion_private_key"},
+			},
+			"client_assertion_signing_algorithm": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "Algorithm used to sign the JWT client assertion. Defaults to `RS256` for RSA keys and `ES256` for EC keys.",
+				ValidateFunc: validation.StringInSlice([]string{"RS256", "ES256"}, false),
+			},
+			"client_assertion_key_id": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "Key ID (`kid` header) of the JWT client assertion, used by Keycloak to pick the verification key from the client's JWKS.",
+			},
+			"tls_client_certificate": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Description:  "PEM encoded certificate used for mutual TLS. Allows authenticating with the `X509 Certificate` client authenticator instead of a client secret.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_CERTIFICATE", ""),
+				RequiredWith: []string{"tls_client_private_key"},
+			},
+			"tls_client_private_key": {
+				Optional:     true,
+				Type:         schema.TypeString,
+				Sensitive:    true,
+				Description:  "PEM encoded private key of the certificate used for mutual TLS.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_TLS_CLIENT_PRIVATE_KEY", ""),
+				RequiredWith: []string{"tls_client_certificate"},
+			},
+			"max_retries": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of times a request to Keycloak is retried after a connection error, a 429 or a 5xx response. Set to 0 to disable retries.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_RETRIES", 3),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_min": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Minimum time (in seconds) to wait before retrying a request. The wait time doubles with every attempt.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_WAIT_MIN", 1),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_wait_max": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum time (in seconds) to wait before retrying a request, including waits requested by a Retry-After header.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_RETRY_WAIT_MAX", 30),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"retry_jitter": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "When true, a random jitter is applied to the wait time between retries.",
+				Default:     true,
+			},
+			"retry_non_idempotent_requests": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "When true, POST requests are retried after any retryable failure. By default, they are only retried when Keycloak could not have processed them (connection refused, 429 or 503).",
+				Default:     false,
+			},
+			"cache_reads": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "When true, the responses of list endpoints are cached until a request that changes the same collection is sent, so they are only fetched once per run.",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_CACHE_READS", false),
+			},
+			"read_only": {
+				Optional:    true,
+				Type:        schema.TypeBool,
+				Description: "When true, every request that could change something in Keycloak fails before it is sent, so only GET requests reach the admin API.",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_READ_ONLY", false),
+			},
+			"backup_directory": {
+				Optional:    true,
+				Type:        schema.TypeString,
+				Description: "When set, realms, clients, identity providers and LDAP user federations are written to a JSON file in this directory before they are deleted.",
+				DefaultFunc: schema.EnvDefaultFunc("KEYCLOAK_BACKUP_DIRECTORY", ""),
+			},
+			"max_concurrent_requests": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of requests sent to Keycloak at the same time, regardless of Terraform's parallelism. Set to 0 for no limit.",
+				DefaultFunc:  schema.EnvDefaultFunc("KEYCLOAK_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"max_idle_connections": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Maximum number of idle connections to Keycloak that are kept open to be reused by later requests.",
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"idle_connection_timeout": {
+				Optional:     true,
+				Type:         schema.TypeInt,
+				Description:  "Time (in seconds) after which an idle connection to Keycloak is closed. Set to 0 to keep idle connections open.",
+				Default:      90,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 		},
 	}
 
@@ -221,12 +387,71 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 		for k, v := range data.Get("additional_headers").(map[string]interface{}) {
 			additionalHeaders[k] = v.(string)
 		}
+		var redactedLogFields []string
+		for _, field := range data.Get("redacted_log_fields").([]interface{}) {
+			redactedLogFields = append(redactedLogFields, field.(string))
+		}
+		harRecordFile := data.Get("har_record_file").(string)
+		harReplayFile := data.Get("har_replay_file").(string)
+		accessToken := data.Get("access_token").(string)
+		var accessTokenCommand []string
+		for _, arg := range data.Get("access_token_command").([]interface{}) {
+			accessTokenCommand = append(accessTokenCommand, arg.(string))
+		}
+		tlsClientCertificate := data.Get("tls_client_certificate").(string)
+		tlsClientPrivateKey := data.Get("tls_client_private_key").(string)
+		retryPolicy := keycloak.RetryPolicy{
+			MaxRetries:         data.Get("max_retries").(int),
+			MinBackoff:         time.Second * time.Duration(data.Get("retry_wait_min").(int)),
+			MaxBackoff:         time.Second * time.Duration(data.Get("retry_wait_max").(int)),
+			Jitter:             data.Get("retry_jitter").(bool),
+			RetryNonIdempotent: data.Get("retry_non_idempotent_requests").(bool),
+		}
+		cacheReads := data.Get("cache_reads").(bool)
+		readOnly := data.Get("read_only").(bool)
+		backupDirectory := data.Get("backup_directory").(string)
+		connectionPool := keycloak.ConnectionPool{
+			MaxConcurrentRequests: data.Get("max_concurrent_requests").(int),
+			MaxIdleConnections:    data.Get("max_idle_connections").(int),
+			IdleConnectionTimeout: time.Second * time.Duration(data.Get("idle_connection_timeout").(int)),
+		}
 
 		var diags diag.Diagnostics
 
+		if retryPolicy.MinBackoff > retryPolicy.MaxBackoff {
+			return nil, append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "error initializing keycloak provider",
+				Detail:   fmt.Sprintf("retry_wait_min (%d) must not be greater than retry_wait_max (%d)", data.Get("retry_wait_min").(int), data.Get("retry_wait_max").(int)),
+			})
+		}
+
+		clientAssertion, err := getClientAssertion(data)
+		if err != nil {
+			return nil, append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "error initializing keycloak provider",
+				Detail:   err.Error(),
+			})
+		}
+
 		userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", provider.TerraformVersion, meta.SDKVersionString())
 
-		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, clientId, clientSecret, realm, username, password, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, userAgent, redHatSSO, additionalHeaders)
+		keycloakClient, err := keycloak.NewKeycloakClient(ctx, url, basePath, clientId, clientSecret, realm, username, password, initialLogin, clientTimeout, rootCaCertificate, tlsInsecureSkipVerify, userAgent, redHatSSO, additionalHeaders, keycloak.KeycloakClientOptions{
+			RetryPolicy:          retryPolicy,
+			ClientAssertion:      clientAssertion,
+			TlsClientCertificate: tlsClientCertificate,
+			TlsClientKey:         tlsClientPrivateKey,
+			AccessToken:          accessToken,
+			AccessTokenCommand:   accessTokenCommand,
+			RedactedLogFields:    redactedLogFields,
+			HarRecordFile:        harRecordFile,
+			HarReplayFile:        harReplayFile,
+			ConnectionPool:       connectionPool,
+			CacheReads:           cacheReads,
+			ReadOnly:             readOnly,
+			BackupDirectory:      backupDirectory,
+		})
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
@@ -240,3 +465,22 @@ func KeycloakProvider(client *keycloak.KeycloakClient) *schema.Provider {
 
 	return provider
 }
+
+func getClientAssertion(data *schema.ResourceData) (*keycloak.ClientAssertion, error) {
+	privateKey := data.Get("client_assertion_private_key").(string)
+
+	if privateKeyFile := data.Get("client_assertion_private_key_file").(string); privateKeyFile != "" {
+		contents, err := ioutil.ReadFile(privateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client assertion private key file: %v", err)
+		}
+
+		privateKey = string(contents)
+	}
+
+	if privateKey == "" {
+		return nil, nil
+	}
+
+	return keycloak.NewClientAssertion(privateKey, data.Get("client_assertion_signing_algorithm").(string), data.Get("client_assertion_key_id").(string))
+}
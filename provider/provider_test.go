@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak/keycloaktest"
 	"os"
 	"testing"
 )
@@ -19,6 +20,9 @@ var testAccRealmTwo *keycloak.Realm
 var testAccRealmUserFederation *keycloak.Realm
 var testCtx context.Context
 
+// testFakeKeycloak is the fake Keycloak that the tests run against when KEYCLOAK_URL isn't set
+var testFakeKeycloak *keycloaktest.Server
+
 var requiredEnvironmentVariables = []string{
 	"KEYCLOAK_CLIENT_ID",
 	"KEYCLOAK_CLIENT_SECRET",
@@ -28,19 +32,55 @@ var requiredEnvironmentVariables = []string{
 
 func init() {
 	testCtx = context.Background()
+}
+
+// newTestKeycloakClient logs in to the Keycloak the acceptance tests run against, or to an in-memory fake Keycloak when
+// KEYCLOAK_URL isn't set, so unit tests can use the provider too. The returned func stops the fake.
+func newTestKeycloakClient() (*keycloak.KeycloakClient, func()) {
+	url := os.Getenv("KEYCLOAK_URL")
+	clientId := os.Getenv("KEYCLOAK_CLIENT_ID")
+	clientSecret := os.Getenv("KEYCLOAK_CLIENT_SECRET")
+	realm := os.Getenv("KEYCLOAK_REALM")
+	stop := func() {}
+
+	if url == "" {
+		server := keycloaktest.Start()
+		stop = server.Close
+		testFakeKeycloak = server
+
+		url = server.URL
+		clientId = server.ClientId
+		clientSecret = server.ClientSecret
+		realm = "master"
+
+		// the url attribute of the provider is required, even though the client is passed in
+		os.Setenv("KEYCLOAK_URL", url)
+	}
+
 	userAgent := fmt.Sprintf("HashiCorp Terraform/%s (+https://www.terraform.io) Terraform Plugin SDK/%s", schema.Provider{}.TerraformVersion, meta.SDKVersionString())
-	keycloakClient, _ = keycloak.NewKeycloakClient(testCtx, os.Getenv("KEYCLOAK_URL"), "", os.Getenv("KEYCLOAK_CLIENT_ID"), os.Getenv("KEYCLOAK_CLIENT_SECRET"), os.Getenv("KEYCLOAK_REALM"), "", "", true, 5, "", false, userAgent, false, map[string]string{
+	client, err := keycloak.NewKeycloakClient(testCtx, url, "", clientId, clientSecret, realm, "", "", true, 5, "", false, userAgent, false, map[string]string{
 		"foo": "bar",
-	})
+	}, keycloak.KeycloakClientOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create the Keycloak client: %s\n", err)
+		stop()
+		os.Exit(1)
+	}
+
+	return client, stop
+}
+
+func TestMain(m *testing.M) {
+	var stop func()
+	keycloakClient, stop = newTestKeycloakClient()
+
 	testAccProvider = KeycloakProvider(keycloakClient)
 	testAccProviderFactories = map[string]func() (*schema.Provider, error){
 		"keycloak": func() (*schema.Provider, error) {
 			return testAccProvider, nil
 		},
 	}
-}
 
-func TestMain(m *testing.M) {
 	testAccRealm = createTestRealm(testCtx)
 	testAccRealmTwo = createTestRealm(testCtx)
 	testAccRealmUserFederation = createTestRealm(testCtx)
@@ -62,6 +102,8 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	stop()
+
 	os.Exit(code)
 }
 
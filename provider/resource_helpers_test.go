@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// These helpers plan, apply, refresh and import a single resource, and read data sources, the same way Terraform does,
+// but without the Terraform CLI, so they can be tested against the fake Keycloak on any machine.
+
+// testRequireFakeKeycloak skips tests of features that the Keycloak the acceptance tests run against doesn't have, such as
+// organizations, which are served by the phasetwo extension
+func testRequireFakeKeycloak(t *testing.T) {
+	if testFakeKeycloak == nil {
+		t.Skip("only runs against the fake Keycloak, unset KEYCLOAK_URL to run it")
+	}
+}
+
+// testRequireTerraform skips tests that run the Terraform CLI when it isn't installed. resource.UnitTest would otherwise
+// try to download it, and abort every test of the package when it can't.
+func testRequireTerraform(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		return
+	}
+
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("the Terraform CLI isn't installed, set TF_ACC_TERRAFORM_PATH or add terraform to the PATH to run it")
+	}
+}
+
+// testResourcePlan returns the changes that applying config to a resource with the given state would make
+func testResourcePlan(t *testing.T, resourceType string, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+
+	diff, err := testAccProvider.ResourcesMap[resourceType].Diff(testCtx, state, terraform.NewResourceConfigRaw(config), keycloakClient)
+	if err != nil {
+		t.Fatalf("failed to plan %s: %s", resourceType, err)
+	}
+
+	return diff
+}
+
+// testResourceApply plans and applies config, and returns the new state of the resource
+func testResourceApply(t *testing.T, resourceType string, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	diff := testResourcePlan(t, resourceType, state, config)
+	if diff.Empty() {
+		return state
+	}
+
+	newState, diags := testAccProvider.ResourcesMap[resourceType].Apply(testCtx, state, diff, keycloakClient)
+	if diags.HasError() {
+		t.Fatalf("failed to apply %s: %v", resourceType, diags)
+	}
+
+	return newState
+}
+
+// testResourceApplyError plans and applies config, and returns the error diagnostics of the apply, along with the state
+// that Terraform keeps after the failure
+func testResourceApplyError(t *testing.T, resourceType string, state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+
+	newState, diags := testAccProvider.ResourcesMap[resourceType].Apply(testCtx, state, testResourcePlan(t, resourceType, state, config), keycloakClient)
+	if !diags.HasError() {
+		t.Fatalf("expected applying %s to fail", resourceType)
+	}
+
+	return newState, diags
+}
+
+// testResourceRefresh reads the resource, and returns nil when it no longer exists
+func testResourceRefresh(t *testing.T, resourceType string, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+
+	newState, diags := testAccProvider.ResourcesMap[resourceType].RefreshWithoutUpgrade(testCtx, state, keycloakClient)
+	if diags.HasError() {
+		t.Fatalf("failed to refresh %s: %v", resourceType, diags)
+	}
+
+	return newState
+}
+
+// testResourceImport imports a resource with the given id, and refreshes it like Terraform does after an import
+func testResourceImport(t *testing.T, resourceType, id string) *terraform.InstanceState {
+	t.Helper()
+
+	resource := testAccProvider.ResourcesMap[resourceType]
+
+	imported, err := resource.Importer.StateContext(testCtx, resource.Data(&terraform.InstanceState{ID: id}), keycloakClient)
+	if err != nil {
+		t.Fatalf("failed to import %s %s: %s", resourceType, id, err)
+	}
+	if len(imported) != 1 {
+		t.Fatalf("expected a single %s to be imported, got %d", resourceType, len(imported))
+	}
+
+	return testResourceRefresh(t, resourceType, imported[0].State())
+}
+
+// testResourceDestroy deletes the resource
+func testResourceDestroy(t *testing.T, resourceType string, state *terraform.InstanceState) {
+	t.Helper()
+
+	_, diags := testAccProvider.ResourcesMap[resourceType].Apply(testCtx, state, &terraform.InstanceDiff{Destroy: true}, keycloakClient)
+	if diags.HasError() {
+		t.Fatalf("failed to destroy %s: %v", resourceType, diags)
+	}
+}
+
+// testDataSourceRead reads a data source with the given config, and returns its state
+func testDataSourceRead(t *testing.T, dataSourceType string, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+
+	dataSource := testAccProvider.DataSourcesMap[dataSourceType]
+
+	diff, err := dataSource.Diff(testCtx, nil, terraform.NewResourceConfigRaw(config), keycloakClient)
+	if err != nil {
+		t.Fatalf("failed to plan %s: %s", dataSourceType, err)
+	}
+
+	state, diags := dataSource.ReadDataApply(testCtx, diff, keycloakClient)
+	if diags.HasError() {
+		t.Fatalf("failed to read %s: %v", dataSourceType, diags)
+	}
+
+	return state
+}
@@ -17,6 +17,7 @@ func resourceKeycloakDefaultRoles() *schema.Resource {
 		ReadContext:   resourceKeycloakDefaultRolesRead,
 		DeleteContext: resourceKeycloakDefaultRolesDelete,
 		UpdateContext: resourceKeycloakDefaultRolesReconcile,
+		CustomizeDiff: requireCapabilities(keycloak.CapabilityDefaultRoles),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceKeycloakDefaultRolesImport,
 		},
@@ -87,12 +88,7 @@ func resourceKeycloakDefaultRolesRead(ctx context.Context, data *schema.Resource
 func resourceKeycloakDefaultRolesReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	if ok, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_13); !ok && err == nil {
-		return diag.Diagnostics{{
-			Severity: diag.Error,
-			Summary:  "this resource requires Keycloak v13 or higher",
-		}}
-	} else if err != nil {
+	if err := keycloakClient.RequireCapability(ctx, keycloak.CapabilityDefaultRoles); err != nil {
 		return diag.FromErr(err)
 	}
 
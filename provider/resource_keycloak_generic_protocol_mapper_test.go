@@ -76,6 +76,12 @@ func TestAccKeycloakGenericProtocolMapper_import(t *testing.T) {
 				ImportStateVerify: true,
 				ImportStateIdFunc: getGenericProtocolMapperIdForClient(resourceName),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/client/" + clientId + "/" + mapperName,
+			},
 		},
 	})
 }
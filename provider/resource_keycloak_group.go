@@ -140,18 +140,23 @@ func resourceKeycloakGroupDelete(ctx context.Context, data *schema.ResourceData,
 func resourceKeycloakGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{groupId}}")
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{groupId}}, {{realmId}}/{{groupPath}}")
 	}
 
-	_, err := keycloakClient.GetGroup(ctx, parts[0], parts[1])
+	id, err := resolveGroupImportKey(ctx, keycloakClient, realmId, key)
 	if err != nil {
 		return nil, err
 	}
 
-	d.Set("realm_id", parts[0])
-	d.SetId(parts[1])
+	_, err = keycloakClient.GetGroup(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
 
 	diagnostics := resourceKeycloakGroupRead(ctx, d, meta)
 	if diagnostics.HasError() {
@@ -205,6 +205,12 @@ func runTestNestedGroup(t *testing.T, parentGroupName, firstChildGroupName, seco
 				ImportStateVerify:   true,
 				ImportStateIdPrefix: testAccRealm.Realm + "/",
 			},
+			{
+				ResourceName:      secondChildGroupResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "//" + parentGroupName + "/" + firstChildGroupName + "/" + secondChildGroupName,
+			},
 			{
 				Config: testKeycloakGroup_nested(parentGroupName, firstChildGroupName, secondChildGroupName, parentGroupResource),
 				Check: resource.ComposeTestCheckFunc(
@@ -156,7 +156,7 @@ func getLdapGroupMapperFromData(ctx context.Context, keycloakClient *keycloak.Ke
 		MappedGroupAttributes:           mappedGroupAttributes,
 		DropNonExistingGroupsDuringSync: data.Get("drop_non_existing_groups_during_sync").(bool),
 	}
-	versionOk, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_11)
+	versionOk, err := keycloakClient.CapabilityIsAvailable(ctx, keycloak.CapabilityLdapGroupMapperGroupsPath)
 	if err != nil {
 		return nil, err
 	}
@@ -190,7 +190,7 @@ func setLdapGroupMapperData(ctx context.Context, keycloakClient *keycloak.Keyclo
 	data.Set("mapped_group_attributes", ldapGroupMapper.MappedGroupAttributes)
 	data.Set("drop_non_existing_groups_during_sync", ldapGroupMapper.DropNonExistingGroupsDuringSync)
 
-	versionOk, err := keycloakClient.VersionIsGreaterThanOrEqualTo(ctx, keycloak.Version_11)
+	versionOk, err := keycloakClient.CapabilityIsAvailable(ctx, keycloak.CapabilityLdapGroupMapperGroupsPath)
 	if err != nil {
 		return err
 	}
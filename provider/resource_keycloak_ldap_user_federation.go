@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"strings"
 
@@ -479,7 +480,7 @@ func resourceKeycloakLdapUserFederationCreate(ctx context.Context, data *schema.
 
 	err = keycloakClient.NewLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, ldapUserFederationAttributePath)
 	}
 
 	if data.Get("delete_default_mappers").(bool) {
@@ -530,7 +531,7 @@ func resourceKeycloakLdapUserFederationUpdate(ctx context.Context, data *schema.
 
 	err = keycloakClient.UpdateLdapUserFederation(ctx, realmId, ldap)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, ldapUserFederationAttributePath)
 	}
 
 	setLdapUserFederationData(data, ldap, realmId)
@@ -544,6 +545,11 @@ func resourceKeycloakLdapUserFederationDelete(ctx context.Context, data *schema.
 	realmId := data.Get("realm_id").(string)
 	id := data.Id()
 
+	err := keycloakClient.BackupLdapUserFederation(ctx, realmId, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.FromErr(keycloakClient.DeleteLdapUserFederation(ctx, realmId, id))
 }
 
@@ -581,3 +587,8 @@ func resourceKeycloakLdapUserFederationImport(ctx context.Context, d *schema.Res
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// maps the fields and config keys named in validation errors to the arguments of this resource
+func ldapUserFederationAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakLdapUserFederation().Schema, nil)(field)
+}
@@ -4,10 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/imdario/mergo"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
 	"reflect"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
@@ -540,12 +540,12 @@ func resourceKeycloakOpenidClientCreate(ctx context.Context, data *schema.Resour
 
 		err = keycloakClient.UpdateOpenidClient(ctx, client)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(err, openidClientAttributePath)
 		}
 	} else {
 		err = keycloakClient.NewOpenidClient(ctx, client)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(err, openidClientAttributePath)
 		}
 	}
 
@@ -591,7 +591,7 @@ func resourceKeycloakOpenidClientUpdate(ctx context.Context, data *schema.Resour
 
 	err = keycloakClient.UpdateOpenidClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, openidClientAttributePath)
 	}
 
 	err = setOpenidClientData(ctx, keycloakClient, data, client)
@@ -611,25 +611,35 @@ func resourceKeycloakOpenidClientDelete(ctx context.Context, data *schema.Resour
 	realmId := data.Get("realm_id").(string)
 	id := data.Id()
 
+	err := keycloakClient.BackupClient(ctx, realmId, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.FromErr(keycloakClient.DeleteOpenidClient(ctx, realmId, id))
 }
 
 func resourceKeycloakOpenidClientImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{openidClientId}}")
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{openidClientId}}, {{realmId}}/{{clientId}}")
 	}
 
-	_, err := keycloakClient.GetOpenidClient(ctx, parts[0], parts[1])
+	id, err := resolveClientImportKey(ctx, keycloakClient, realmId, key)
 	if err != nil {
 		return nil, err
 	}
 
-	d.Set("realm_id", parts[0])
+	_, err = keycloakClient.GetOpenidClient(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
 	d.Set("import", false)
-	d.SetId(parts[1])
+	d.SetId(id)
 
 	diagnostics := resourceKeycloakOpenidClientRead(ctx, d, meta)
 	if diagnostics.HasError() {
@@ -638,3 +648,13 @@ func resourceKeycloakOpenidClientImport(ctx context.Context, d *schema.ResourceD
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// maps the fields named in validation errors to the arguments of this resource
+func openidClientAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakOpenidClient().Schema, map[string]string{
+		"secret":                    "client_secret",
+		"redirectUris":              "valid_redirect_uris",
+		"post.logout.redirect.uris": "valid_post_logout_redirect_uris",
+		"frontchannelLogout":        "frontchannel_logout_enabled",
+	})(field)
+}
@@ -3,10 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -102,7 +102,7 @@ func resourceKeycloakOpenidClientScopeCreate(ctx context.Context, data *schema.R
 
 	err := keycloakClient.NewOpenidClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, openidClientScopeAttributePath)
 	}
 
 	setOpenidClientScopeData(data, clientScope)
@@ -133,7 +133,7 @@ func resourceKeycloakOpenidClientScopeUpdate(ctx context.Context, data *schema.R
 
 	err := keycloakClient.UpdateOpenidClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, openidClientScopeAttributePath)
 	}
 
 	setOpenidClientScopeData(data, clientScope)
@@ -150,14 +150,26 @@ func resourceKeycloakOpenidClientScopeDelete(ctx context.Context, data *schema.R
 	return diag.FromErr(keycloakClient.DeleteOpenidClientScope(ctx, realmId, id))
 }
 
-func resourceKeycloakOpenidClientScopeImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{openidClientScopeId}}")
+func resourceKeycloakOpenidClientScopeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{openidClientScopeId}}, {{realmId}}/{{openidClientScopeName}}")
 	}
 
-	d.Set("realm_id", parts[0])
-	d.SetId(parts[1])
+	id, err := resolveClientScopeImportKey(ctx, keycloakClient, realmId, "openid-connect", key)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// maps the fields named in validation errors to the arguments of this resource
+func openidClientScopeAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakOpenidClientScope().Schema, nil)(field)
+}
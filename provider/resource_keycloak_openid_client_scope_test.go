@@ -31,6 +31,12 @@ func TestAccKeycloakClientScope_basic(t *testing.T) {
 				ImportStateVerify:   true,
 				ImportStateIdPrefix: testAccRealm.Realm + "/",
 			},
+			{
+				ResourceName:      "keycloak_openid_client_scope.client_scope",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/" + clientScopeName,
+			},
 		},
 	})
 }
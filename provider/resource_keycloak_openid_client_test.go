@@ -33,6 +33,13 @@ func TestAccKeycloakOpenidClient_basic(t *testing.T) {
 				ImportStateIdPrefix:     testAccRealm.Realm + "/",
 				ImportStateVerifyIgnore: []string{"exclude_session_state_from_auth_response"},
 			},
+			{
+				ResourceName:            "keycloak_openid_client.client",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           testAccRealm.Realm + "/" + clientId,
+				ImportStateVerifyIgnore: []string{"exclude_session_state_from_auth_response"},
+			},
 		},
 	})
 }
@@ -41,11 +41,14 @@ func resourceKeycloakOrganization() *schema.Resource {
 				Optional: true,
 			},
 			"domains": {
-				Type:     schema.TypeSet,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				MinItems: 1,
-				Set:      schema.HashString,
-				Optional: true,
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "The domains of the organization. Removing this attribute leaves the current domains in place, so set the domains that should remain instead.",
+				// domains can also be managed with keycloak_organization_domain, in which case this is left unset
+				Computed: true,
 			},
 			"attributes": {
 				Type:     schema.TypeMap,
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationDomainCreate,
+		ReadContext:   resourceKeycloakOrganizationDomainRead,
+		DeleteContext: resourceKeycloakOrganizationDomainDelete,
+		UpdateContext: resourceKeycloakOrganizationDomainUpdate,
+		// This resource can be imported using {{realmName}}/{{organizationId}}/{{domainName}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationDomainImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, Keycloak is asked to verify the domain through its DNS TXT record on every apply until the domain is verified.",
+			},
+			"verified": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"verification_record_key": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"verification_record_value": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"verification_record_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customdiff.ComputedIf("verified", func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) bool {
+			return d.Get("verify").(bool) && !d.Get("verified").(bool)
+		}),
+	}
+}
+
+func mapFromOrganizationDomainToData(data *schema.ResourceData, domain *keycloak.OrganizationDomain) {
+	data.SetId(domain.DomainName)
+	data.Set("name", domain.DomainName)
+	data.Set("verified", domain.Verified)
+	data.Set("verification_record_key", domain.RecordKey)
+	data.Set("verification_record_value", domain.RecordValue)
+	data.Set("verification_record_type", domain.RecordType)
+}
+
+func resourceKeycloakOrganizationDomainCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	domainName := data.Get("name").(string)
+
+	err := keycloakClient.AddOrganizationDomain(ctx, realm, orgId, domainName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(domainName)
+
+	return resourceKeycloakOrganizationDomainUpdate(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationDomainRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	domain, err := keycloakClient.GetOrganizationDomain(ctx, realm, orgId, data.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	mapFromOrganizationDomainToData(data, domain)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationDomainUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	domain, err := keycloakClient.GetOrganizationDomain(ctx, realm, orgId, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if data.Get("verify").(bool) && !domain.Verified {
+		domain, err = keycloakClient.VerifyOrganizationDomain(ctx, realm, orgId, data.Id())
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	mapFromOrganizationDomainToData(data, domain)
+
+	return nil
+}
+
+func resourceKeycloakOrganizationDomainDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	err := keycloakClient.RemoveOrganizationDomain(ctx, realm, orgId, data.Id())
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationDomainImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid import. Supported import formats: {{realmName}}/{{organizationId}}/{{domainName}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationDomain(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("verify", false)
+	d.SetId(parts[2])
+
+	diagnostics := resourceKeycloakOrganizationDomainRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+func TestKeycloakOrganizationDomain_basic(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization := testResourceApply(t, "keycloak_organization", nil, map[string]interface{}{
+		"realm":   testAccRealm.Realm,
+		"name":    acctest.RandomWithPrefix("tf-acc"),
+		"domains": testStringSet("example.com"),
+	})
+	defer testResourceDestroy(t, "keycloak_organization", organization)
+
+	config := map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.ID,
+		"name":            "example.org",
+		"verify":          true,
+	}
+
+	domain := testResourceApply(t, "keycloak_organization_domain", nil, config)
+	if domain.ID != "example.org" || domain.Attributes["verified"] != "false" || domain.Attributes["verification_record_type"] != "TXT" {
+		t.Fatalf("unexpected domain %v", domain.Attributes)
+	}
+
+	// domains added with keycloak_organization_domain don't show up as drift of the organization
+	organization = testResourceRefresh(t, "keycloak_organization", organization)
+	if expected := []string{"example.com", "example.org"}; !reflect.DeepEqual(testStateSet(organization, "domains"), expected) {
+		t.Fatalf("expected the organization to have the domains %v, got %v", expected, testStateSet(organization, "domains"))
+	}
+
+	imported := testResourceImport(t, "keycloak_organization_domain", testAccRealm.Realm+"/"+organization.ID+"/example.org")
+	if imported.ID != "example.org" || imported.Attributes["organization_id"] != organization.ID {
+		t.Fatalf("unexpected imported domain %v", imported.Attributes)
+	}
+	if diff := testResourcePlan(t, "keycloak_organization_domain", imported, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.ID,
+		"name":            "example.org",
+	}); !diff.Empty() {
+		t.Fatalf("expected no changes after the import, got %v", diff)
+	}
+
+	testResourceDestroy(t, "keycloak_organization_domain", domain)
+
+	remaining, err := keycloakClient.GetOrganizationDomains(testCtx, testAccRealm.Realm, organization.ID)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(remaining) != 1 || remaining[0].DomainName != "example.com" {
+		t.Fatalf("expected only the domain of the organization itself to be left, got %v", remaining)
+	}
+
+	if state := testResourceRefresh(t, "keycloak_organization_domain", domain); state != nil {
+		t.Fatalf("expected the deleted domain to be removed from the state, got %v", state.Attributes)
+	}
+}
@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationIdentityProvider() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationIdentityProviderCreate,
+		ReadContext:   resourceKeycloakOrganizationIdentityProviderRead,
+		DeleteContext: resourceKeycloakOrganizationIdentityProviderDelete,
+		UpdateContext: resourceKeycloakOrganizationIdentityProviderUpdate,
+		// This resource can be imported using {{realmName}}/{{organizationId}}/{{identityProviderAlias}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationIdentityProviderImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The alias of the OIDC or SAML identity provider to link to the organization.",
+			},
+			"sync_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(syncModes, false),
+			},
+			"post_broker_flow": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"domains": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				Description: "Email domains that home IdP discovery routes to this identity provider.",
+			},
+		},
+	}
+}
+
+func resourceKeycloakOrganizationIdentityProviderCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	link := &keycloak.OrganizationIdentityProviderLink{
+		Alias:          data.Get("alias").(string),
+		SyncMode:       data.Get("sync_mode").(string),
+		PostBrokerFlow: data.Get("post_broker_flow").(string),
+	}
+
+	err := keycloakClient.LinkOrganizationIdentityProvider(ctx, realm, orgId, link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(link.Alias)
+
+	if v, ok := data.GetOk("domains"); ok {
+		err = keycloakClient.SetHomeIdpDiscoveryDomains(ctx, realm, link.Alias, interfaceSliceToStringSlice(v.(*schema.Set).List()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakOrganizationIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationIdentityProviderRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	identityProvider, err := keycloakClient.GetOrganizationIdentityProvider(ctx, realm, orgId, data.Id())
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	data.Set("alias", identityProvider.Alias)
+	data.Set("post_broker_flow", identityProvider.PostBrokerLoginFlowAlias)
+	data.Set("domains", identityProvider.GetHomeIdpDiscoveryDomains())
+	if identityProvider.Config != nil {
+		data.Set("sync_mode", identityProvider.Config.SyncMode)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+
+	if data.HasChange("domains") {
+		err := keycloakClient.SetHomeIdpDiscoveryDomains(ctx, realm, data.Id(), interfaceSliceToStringSlice(data.Get("domains").(*schema.Set).List()))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKeycloakOrganizationIdentityProviderRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationIdentityProviderDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	if data.Get("domains").(*schema.Set).Len() != 0 {
+		err := keycloakClient.SetHomeIdpDiscoveryDomains(ctx, realm, data.Id(), nil)
+		if err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	err := keycloakClient.UnlinkOrganizationIdentityProvider(ctx, realm, orgId, data.Id())
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationIdentityProviderImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid import. Supported import formats: {{realmName}}/{{organizationId}}/{{identityProviderAlias}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationIdentityProvider(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.SetId(parts[2])
+
+	diagnostics := resourceKeycloakOrganizationIdentityProviderRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+func testOidcIdentityProviderConfig(alias, displayName string) map[string]interface{} {
+	return map[string]interface{}{
+		"realm":             testAccRealm.Realm,
+		"alias":             alias,
+		"display_name":      displayName,
+		"authorization_url": "https://example.com/auth",
+		"token_url":         "https://example.com/token",
+		"client_id":         "example_id",
+		"client_secret":     "example_token",
+		// the sync mode of the link is the one of the identity provider itself
+		"sync_mode": "FORCE",
+	}
+}
+
+func TestKeycloakOrganizationIdentityProvider_basic(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization := testResourceApply(t, "keycloak_organization", nil, map[string]interface{}{
+		"realm":   testAccRealm.Realm,
+		"name":    acctest.RandomWithPrefix("tf-acc"),
+		"domains": testStringSet("example.com"),
+	})
+	defer testResourceDestroy(t, "keycloak_organization", organization)
+
+	alias := acctest.RandomWithPrefix("tf-acc")
+	identityProvider := testResourceApply(t, "keycloak_oidc_identity_provider", nil, testOidcIdentityProviderConfig(alias, "before"))
+	defer testResourceDestroy(t, "keycloak_oidc_identity_provider", identityProvider)
+
+	config := map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.ID,
+		"alias":           alias,
+		"sync_mode":       "FORCE",
+		"domains":         testStringSet("example.com", "example.org"),
+	}
+
+	link := testResourceApply(t, "keycloak_organization_identity_provider", nil, config)
+	if link.ID != alias || link.Attributes["sync_mode"] != "FORCE" {
+		t.Fatalf("unexpected organization identity provider %v", link.Attributes)
+	}
+
+	linked, err := keycloakClient.GetOrganizationIdentityProvider(testCtx, testAccRealm.Realm, organization.ID, alias)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if expected := []string{"example.com", "example.org"}; !reflect.DeepEqual(testSorted(linked.GetHomeIdpDiscoveryDomains()...), expected) {
+		t.Fatalf("expected the identity provider to have the domains %v, got %v", expected, linked.GetHomeIdpDiscoveryDomains())
+	}
+
+	// updating the identity provider itself keeps the domains that were set by the link
+	identityProvider = testResourceApply(t, "keycloak_oidc_identity_provider", identityProvider, testOidcIdentityProviderConfig(alias, "after"))
+	if identityProvider.Attributes["display_name"] != "after" {
+		t.Fatalf("expected the identity provider to be updated, got %v", identityProvider.Attributes)
+	}
+	if diff := testResourcePlan(t, "keycloak_organization_identity_provider", testResourceRefresh(t, "keycloak_organization_identity_provider", link), config); !diff.Empty() {
+		t.Fatalf("expected no changes after updating the identity provider, got %v", diff)
+	}
+
+	config["domains"] = testStringSet("example.com")
+	link = testResourceApply(t, "keycloak_organization_identity_provider", link, config)
+	if expected := []string{"example.com"}; !reflect.DeepEqual(testStateSet(link, "domains"), expected) {
+		t.Fatalf("expected the domains %v, got %v", expected, testStateSet(link, "domains"))
+	}
+
+	imported := testResourceImport(t, "keycloak_organization_identity_provider", testAccRealm.Realm+"/"+organization.ID+"/"+alias)
+	if diff := testResourcePlan(t, "keycloak_organization_identity_provider", imported, config); !diff.Empty() {
+		t.Fatalf("expected no changes after the import, got %v", diff)
+	}
+
+	testResourceDestroy(t, "keycloak_organization_identity_provider", link)
+
+	linkedIdentityProviders, err := keycloakClient.GetOrganizationIdentityProviders(testCtx, testAccRealm.Realm, organization.ID)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(linkedIdentityProviders) != 0 {
+		t.Fatalf("expected the identity provider to be unlinked, got %v", linkedIdentityProviders)
+	}
+
+	unlinked, err := keycloakClient.GetIdentityProvider(testCtx, testAccRealm.Realm, alias)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if domains := unlinked.GetHomeIdpDiscoveryDomains(); len(domains) != 0 {
+		t.Fatalf("expected the domains to be removed from the unlinked identity provider, got %v", domains)
+	}
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+const (
+	organizationInvitationStatusPending  = "pending"
+	organizationInvitationStatusAccepted = "accepted"
+)
+
+func resourceKeycloakOrganizationInvitation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationInvitationCreate,
+		ReadContext:   resourceKeycloakOrganizationInvitationRead,
+		DeleteContext: resourceKeycloakOrganizationInvitationDelete,
+		// This resource can be imported using {{realmName}}/{{organizationId}}/{{invitationId}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationInvitationImport,
+		},
+		// invitations cannot be changed once they are sent, so every argument forces a new invitation
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"email": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"roles": {
+				Type:        schema.TypeSet,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Organization roles granted to the invitee when they accept the invitation.",
+			},
+			"redirect_uri": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"send": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "When true, Keycloak emails the invitation to the invitee.",
+				// the invitation is only sent when it is created, and whether it was can't be read back
+				DiffSuppressFunc: func(_, _, _ string, d *schema.ResourceData) bool {
+					return d.Id() != ""
+				},
+			},
+			"inviter_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Either `pending`, or `accepted` once the invitee has joined the organization.",
+			},
+		},
+	}
+}
+
+func mapFromOrganizationInvitationToData(data *schema.ResourceData, invitation *keycloak.OrganizationInvitation) {
+	data.SetId(invitation.Id)
+	data.Set("organization_id", invitation.OrganizationId)
+	data.Set("email", invitation.Email)
+	data.Set("roles", invitation.Roles)
+	data.Set("redirect_uri", invitation.RedirectUri)
+	data.Set("inviter_id", invitation.InviterId)
+	data.Set("created_at", string(invitation.CreatedAt))
+}
+
+func resourceKeycloakOrganizationInvitationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	invitation := &keycloak.OrganizationInvitation{
+		Email:       data.Get("email").(string),
+		Roles:       interfaceSliceToStringSlice(data.Get("roles").(*schema.Set).List()),
+		RedirectUri: data.Get("redirect_uri").(string),
+		Send:        data.Get("send").(bool),
+	}
+
+	err := keycloakClient.NewOrganizationInvitation(ctx, realm, orgId, invitation)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId(invitation.Id)
+
+	return resourceKeycloakOrganizationInvitationRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationInvitationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	invitation, err := keycloakClient.GetOrganizationInvitation(ctx, realm, orgId, data.Id())
+	if err == nil {
+		mapFromOrganizationInvitationToData(data, invitation)
+		data.Set("status", organizationInvitationStatusPending)
+
+		return nil
+	}
+
+	if !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	// Keycloak removes invitations once they are accepted. Keep those in state, so that the invitation isn't sent again,
+	// and only treat the invitation as gone when it was revoked or it expired.
+	accepted, err := organizationHasMemberWithEmail(ctx, keycloakClient, realm, orgId, data.Get("email").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	if !accepted {
+		return handleNotFoundError(ctx, &keycloak.ApiError{Code: http.StatusNotFound, Message: fmt.Sprintf("invitation %s not found", data.Id())}, data)
+	}
+
+	data.Set("status", organizationInvitationStatusAccepted)
+
+	return nil
+}
+
+func organizationHasMemberWithEmail(ctx context.Context, keycloakClient *keycloak.KeycloakClient, realm, orgId, email string) (bool, error) {
+	members, err := keycloakClient.GetOrganizationMembers(ctx, realm, orgId)
+	if err != nil {
+		return false, err
+	}
+
+	for _, member := range members {
+		if strings.EqualFold(member.Email, email) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func resourceKeycloakOrganizationInvitationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+
+	// accepted invitations no longer exist, and deleting this resource does not remove the member from the organization
+	if data.Get("status").(string) == organizationInvitationStatusAccepted {
+		return nil
+	}
+
+	err := keycloakClient.DeleteOrganizationInvitation(ctx, realm, orgId, data.Id())
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationInvitationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid import. Supported import formats: {{realmName}}/{{organizationId}}/{{invitationId}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationInvitation(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.SetId(parts[2])
+
+	diagnostics := resourceKeycloakOrganizationInvitationRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
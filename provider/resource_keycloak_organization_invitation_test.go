@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestKeycloakOrganizationInvitation_basic(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, _ := testOrganizationWithUsers(t, 0)
+
+	config := map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"email":           "invitee@example.com",
+		"roles":           testStringSet("admin", "viewer"),
+		"redirect_uri":    "https://example.com/welcome",
+	}
+
+	state := testResourceApply(t, "keycloak_organization_invitation", nil, config)
+	if state.Attributes["status"] != "pending" || state.Attributes["send"] != "true" {
+		t.Fatalf("unexpected invitation %v", state.Attributes)
+	}
+
+	invitation, err := keycloakClient.GetOrganizationInvitation(testCtx, testAccRealm.Realm, organization.Id, state.ID)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if !invitation.Send || invitation.Email != "invitee@example.com" || invitation.RedirectUri != "https://example.com/welcome" {
+		t.Fatalf("unexpected invitation %+v", invitation)
+	}
+	if expected := []string{"admin", "viewer"}; !reflect.DeepEqual(testSorted(invitation.Roles...), expected) {
+		t.Fatalf("expected the invitation to grant the roles %v, got %v", expected, invitation.Roles)
+	}
+
+	testResourceDestroy(t, "keycloak_organization_invitation", state)
+
+	if _, err := keycloakClient.GetOrganizationInvitation(testCtx, testAccRealm.Realm, organization.Id, state.ID); !keycloak.ErrorIs404(err) {
+		t.Fatalf("expected the invitation to be revoked, got %v", err)
+	}
+}
+
+func TestKeycloakOrganizationInvitation_import(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, _ := testOrganizationWithUsers(t, 0)
+
+	config := map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"email":           "invitee@example.com",
+		"send":            false,
+	}
+
+	state := testResourceApply(t, "keycloak_organization_invitation", nil, config)
+	defer testResourceDestroy(t, "keycloak_organization_invitation", state)
+
+	invitation, err := keycloakClient.GetOrganizationInvitation(testCtx, testAccRealm.Realm, organization.Id, state.ID)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if invitation.Send {
+		t.Fatalf("expected the invitation not to be sent")
+	}
+
+	imported := testResourceImport(t, "keycloak_organization_invitation", testAccRealm.Realm+"/"+organization.Id+"/"+state.ID)
+	if imported.ID != state.ID || imported.Attributes["email"] != "invitee@example.com" || imported.Attributes["status"] != "pending" {
+		t.Fatalf("unexpected imported invitation %v", imported.Attributes)
+	}
+
+	// whether the invitation was sent can't be read back, so neither value of send replaces the imported invitation
+	for _, send := range []bool{true, false} {
+		config["send"] = send
+		if diff := testResourcePlan(t, "keycloak_organization_invitation", imported, config); !diff.Empty() {
+			t.Fatalf("expected no changes after the import with send = %t, got %v", send, diff)
+		}
+	}
+}
+
+func TestKeycloakOrganizationInvitation_acceptedOrRevoked(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, _ := testOrganizationWithUsers(t, 0)
+
+	accepted := testResourceApply(t, "keycloak_organization_invitation", nil, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"email":           "accepted@example.com",
+	})
+	revoked := testResourceApply(t, "keycloak_organization_invitation", nil, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"email":           "revoked@example.com",
+	})
+
+	// Keycloak removes invitations once the invitee joins the organization
+	user := &keycloak.User{
+		RealmId:  testAccRealm.Realm,
+		Username: acctest.RandomWithPrefix("tf-acc"),
+		Email:    "Accepted@example.com",
+		Enabled:  true,
+	}
+	if err := keycloakClient.NewUser(testCtx, user); err != nil {
+		t.Fatalf("%s", err)
+	}
+	defer keycloakClient.DeleteUser(testCtx, testAccRealm.Realm, user.Id)
+
+	if err := keycloakClient.AddUsersToOrganization(testCtx, testAccRealm.Realm, organization.Id, []string{user.Id}); err != nil {
+		t.Fatalf("%s", err)
+	}
+	for _, invitationId := range []string{accepted.ID, revoked.ID} {
+		if err := keycloakClient.DeleteOrganizationInvitation(testCtx, testAccRealm.Realm, organization.Id, invitationId); err != nil {
+			t.Fatalf("%s", err)
+		}
+	}
+
+	accepted = testResourceRefresh(t, "keycloak_organization_invitation", accepted)
+	if accepted == nil || accepted.Attributes["status"] != "accepted" {
+		t.Fatalf("expected the accepted invitation to be kept in the state")
+	}
+
+	if state := testResourceRefresh(t, "keycloak_organization_invitation", revoked); state != nil {
+		t.Fatalf("expected the revoked invitation to be removed from the state, got %v", state.Attributes)
+	}
+
+	// destroying an accepted invitation doesn't remove the member from the organization
+	testResourceDestroy(t, "keycloak_organization_invitation", accepted)
+
+	members, err := keycloakClient.GetOrganizationMembers(testCtx, testAccRealm.Realm, organization.Id)
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	if len(members) != 1 || members[0].Id != user.Id {
+		t.Fatalf("expected the invitee to stay a member of the organization, got %v", members)
+	}
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMemberReconcile,
+		ReadContext:   resourceKeycloakOrganizationMemberRead,
+		DeleteContext: resourceKeycloakOrganizationMemberDelete,
+		UpdateContext: resourceKeycloakOrganizationMemberReconcile,
+		// This resource can be imported using {{realmName}}/{{organizationId}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMemberImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Required: true,
+			},
+			// when false, members of the organization that are not managed by this resource are left alone
+			"exhaustive": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceKeycloakOrganizationMemberRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	userIds := data.Get("user_ids").(*schema.Set)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	members, err := keycloakClient.GetOrganizationMembers(ctx, realm, orgId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var memberIds []string
+	for _, member := range members {
+		// only add members that we care about
+		if exhaustive || userIds.Contains(member.Id) {
+			memberIds = append(memberIds, member.Id)
+		}
+	}
+
+	data.Set("user_ids", memberIds)
+	data.SetId(organizationMemberId(realm, orgId))
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMemberReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	userIds := interfaceSliceToStringSlice(data.Get("user_ids").(*schema.Set).List())
+	exhaustive := data.Get("exhaustive").(bool)
+
+	if data.HasChange("user_ids") {
+		o, n := data.GetChange("user_ids")
+		removed := interfaceSliceToStringSlice(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+		if err := keycloakClient.RemoveUsersFromOrganization(ctx, realm, orgId, removed); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	members, err := keycloakClient.GetOrganizationMembers(ctx, realm, orgId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var memberIds []string
+	for _, member := range members {
+		memberIds = append(memberIds, member.Id)
+	}
+
+	remove := stringArrayDifference(memberIds, userIds)
+	add := stringArrayDifference(userIds, memberIds)
+
+	if err := keycloakClient.AddUsersToOrganization(ctx, realm, orgId, add); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if exhaustive {
+		if err := keycloakClient.RemoveUsersFromOrganization(ctx, realm, orgId, remove); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(organizationMemberId(realm, orgId))
+
+	return resourceKeycloakOrganizationMemberRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMemberDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	userIds := interfaceSliceToStringSlice(data.Get("user_ids").(*schema.Set).List())
+
+	err := keycloakClient.RemoveUsersFromOrganization(ctx, realm, orgId, userIds)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMemberImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid import. Supported import formats: {{realmName}}/{{organizationId}}")
+	}
+
+	_, err := keycloakClient.GetOrganization(ctx, parts[0], parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("exhaustive", true)
+
+	diagnostics := resourceKeycloakOrganizationMemberRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func organizationMemberId(realm, orgId string) string {
+	return fmt.Sprintf("%s/%s", realm, orgId)
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakOrganizationMemberRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakOrganizationMemberRoleReconcile,
+		ReadContext:   resourceKeycloakOrganizationMemberRoleRead,
+		DeleteContext: resourceKeycloakOrganizationMemberRoleDelete,
+		UpdateContext: resourceKeycloakOrganizationMemberRoleReconcile,
+		// This resource can be imported using {{realmName}}/{{organizationId}}/{{roleName}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakOrganizationMemberRoleImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"organization_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_ids": {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+				Required: true,
+			},
+			// when false, grants of the role that are not managed by this resource are left alone
+			"exhaustive": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+		},
+	}
+}
+
+func resourceKeycloakOrganizationMemberRoleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	roleName := data.Get("role").(string)
+	userIds := data.Get("user_ids").(*schema.Set)
+	exhaustive := data.Get("exhaustive").(bool)
+
+	users, err := keycloakClient.GetOrganizationRoleUsers(ctx, realm, orgId, roleName)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	var grantedUserIds []string
+	for _, user := range users {
+		// only add users that we care about
+		if exhaustive || userIds.Contains(user.Id) {
+			grantedUserIds = append(grantedUserIds, user.Id)
+		}
+	}
+
+	data.Set("user_ids", grantedUserIds)
+	data.SetId(organizationMemberRoleId(realm, orgId, roleName))
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMemberRoleReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	roleName := data.Get("role").(string)
+	userIds := interfaceSliceToStringSlice(data.Get("user_ids").(*schema.Set).List())
+	exhaustive := data.Get("exhaustive").(bool)
+
+	if data.HasChange("user_ids") {
+		o, n := data.GetChange("user_ids")
+		removed := interfaceSliceToStringSlice(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+
+		if err := keycloakClient.RevokeOrganizationRoleFromUsers(ctx, realm, orgId, roleName, removed); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	users, err := keycloakClient.GetOrganizationRoleUsers(ctx, realm, orgId, roleName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var grantedUserIds []string
+	for _, user := range users {
+		grantedUserIds = append(grantedUserIds, user.Id)
+	}
+
+	remove := stringArrayDifference(grantedUserIds, userIds)
+	add := stringArrayDifference(userIds, grantedUserIds)
+
+	if err := keycloakClient.GrantOrganizationRoleToUsers(ctx, realm, orgId, roleName, add); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if exhaustive {
+		if err := keycloakClient.RevokeOrganizationRoleFromUsers(ctx, realm, orgId, roleName, remove); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(organizationMemberRoleId(realm, orgId, roleName))
+
+	return resourceKeycloakOrganizationMemberRoleRead(ctx, data, meta)
+}
+
+func resourceKeycloakOrganizationMemberRoleDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realm := data.Get("realm").(string)
+	orgId := data.Get("organization_id").(string)
+	roleName := data.Get("role").(string)
+	userIds := interfaceSliceToStringSlice(data.Get("user_ids").(*schema.Set).List())
+
+	err := keycloakClient.RevokeOrganizationRoleFromUsers(ctx, realm, orgId, roleName, userIds)
+	if err != nil && !keycloak.ErrorIs404(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKeycloakOrganizationMemberRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("invalid import. Supported import formats: {{realmName}}/{{organizationId}}/{{roleName}}")
+	}
+
+	_, err := keycloakClient.GetOrganizationRole(ctx, parts[0], parts[1], parts[2])
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm", parts[0])
+	d.Set("organization_id", parts[1])
+	d.Set("role", parts[2])
+	d.Set("exhaustive", true)
+
+	diagnostics := resourceKeycloakOrganizationMemberRoleRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func organizationMemberRoleId(realm, orgId, roleName string) string {
+	return fmt.Sprintf("%s/%s/%s", realm, orgId, roleName)
+}
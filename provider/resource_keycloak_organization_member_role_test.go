@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func testOrganizationRoleWithMembers(t *testing.T, members int) (*keycloak.Organization, string, []string) {
+	t.Helper()
+
+	organization, users := testOrganizationWithUsers(t, members)
+	if err := keycloakClient.AddUsersToOrganization(testCtx, testAccRealm.Realm, organization.Id, users); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if err := keycloakClient.NewOrganizationRole(testCtx, testAccRealm.Realm, organization.Id, &keycloak.OrganizationRole{Name: "admin"}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return organization, "admin", users
+}
+
+func TestKeycloakOrganizationMemberRole_grantAndRevoke(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, role, users := testOrganizationRoleWithMembers(t, 3)
+	config := func(exhaustive bool, userIds ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"realm":           testAccRealm.Realm,
+			"organization_id": organization.Id,
+			"role":            role,
+			"user_ids":        testStringSet(userIds...),
+			"exhaustive":      exhaustive,
+		}
+	}
+	grants := func() []string {
+		grants, err := keycloakClient.GetOrganizationRoleUsers(testCtx, testAccRealm.Realm, organization.Id, role)
+		return testSortedUserIds(t, grants, err)
+	}
+
+	state := testResourceApply(t, "keycloak_organization_member_role", nil, config(true, users[0], users[1]))
+	if expected := testSorted(users[0], users[1]); !reflect.DeepEqual(grants(), expected) {
+		t.Fatalf("expected the role to be granted to %v, got %v", expected, grants())
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member_role", state, config(true, users[1]))
+	if expected := []string{users[1]}; !reflect.DeepEqual(grants(), expected) {
+		t.Fatalf("expected the role to be revoked from %s, got %v", users[0], grants())
+	}
+
+	// grants made outside of Terraform are revoked when the resource is exhaustive
+	if err := keycloakClient.GrantOrganizationRoleToUsers(testCtx, testAccRealm.Realm, organization.Id, role, []string{users[2]}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state = testResourceRefresh(t, "keycloak_organization_member_role", state)
+	if diff := testResourcePlan(t, "keycloak_organization_member_role", state, config(true, users[1])); diff.Empty() {
+		t.Fatalf("expected the grant made outside of Terraform to show up as drift")
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member_role", state, config(true, users[1]))
+	if expected := []string{users[1]}; !reflect.DeepEqual(grants(), expected) {
+		t.Fatalf("expected the role to only be granted to %s, got %v", users[1], grants())
+	}
+
+	// and left alone when it isn't
+	if err := keycloakClient.GrantOrganizationRoleToUsers(testCtx, testAccRealm.Realm, organization.Id, role, []string{users[2]}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member_role", state, config(false, users[1]))
+	state = testResourceRefresh(t, "keycloak_organization_member_role", state)
+	if diff := testResourcePlan(t, "keycloak_organization_member_role", state, config(false, users[1])); !diff.Empty() {
+		t.Fatalf("expected no drift, got %v", diff)
+	}
+
+	testResourceDestroy(t, "keycloak_organization_member_role", state)
+	if expected := []string{users[2]}; !reflect.DeepEqual(grants(), expected) {
+		t.Fatalf("expected only the unmanaged grant to be left, got %v", grants())
+	}
+}
+
+func TestKeycloakOrganizationMemberRole_import(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, role, users := testOrganizationRoleWithMembers(t, 2)
+	if err := keycloakClient.GrantOrganizationRoleToUsers(testCtx, testAccRealm.Realm, organization.Id, role, users); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state := testResourceImport(t, "keycloak_organization_member_role", testAccRealm.Realm+"/"+organization.Id+"/"+role)
+	if expected := testSorted(users...); !reflect.DeepEqual(testStateSet(state, "user_ids"), expected) {
+		t.Fatalf("expected the imported user_ids to be %v, got %v", expected, testStateSet(state, "user_ids"))
+	}
+
+	if diff := testResourcePlan(t, "keycloak_organization_member_role", state, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"role":            role,
+		"user_ids":        testStringSet(users...),
+	}); !diff.Empty() {
+		t.Fatalf("expected no changes after the import, got %v", diff)
+	}
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+// testOrganizationWithUsers creates an organization, and users in the same realm that can be added to it
+func testOrganizationWithUsers(t *testing.T, users int) (*keycloak.Organization, []string) {
+	t.Helper()
+
+	organization := &keycloak.Organization{
+		RealmName: testAccRealm.Realm,
+		Name:      acctest.RandomWithPrefix("tf-acc"),
+	}
+	if err := keycloakClient.NewOrganization(testCtx, organization); err != nil {
+		t.Fatalf("%s", err)
+	}
+	t.Cleanup(func() {
+		keycloakClient.DeleteOrganization(testCtx, testAccRealm.Realm, organization.Id)
+	})
+
+	var userIds []string
+	for i := 0; i < users; i++ {
+		user := &keycloak.User{
+			RealmId:  testAccRealm.Realm,
+			Username: acctest.RandomWithPrefix("tf-acc"),
+			Enabled:  true,
+		}
+		if err := keycloakClient.NewUser(testCtx, user); err != nil {
+			t.Fatalf("%s", err)
+		}
+		t.Cleanup(func() {
+			keycloakClient.DeleteUser(testCtx, testAccRealm.Realm, user.Id)
+		})
+
+		userIds = append(userIds, user.Id)
+	}
+
+	return organization, userIds
+}
+
+// testSortedUserIds returns the sorted ids of users returned by the API
+func testSortedUserIds(t *testing.T, users []*keycloak.User, err error) []string {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	userIds := []string{}
+	for _, user := range users {
+		userIds = append(userIds, user.Id)
+	}
+	sort.Strings(userIds)
+
+	return userIds
+}
+
+// testStateSet returns the sorted elements of a set of strings in the state of a resource
+func testStateSet(state *terraform.InstanceState, attribute string) []string {
+	values := []string{}
+	for key, value := range state.Attributes {
+		if strings.HasPrefix(key, attribute+".") && key != attribute+".#" {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+
+	return values
+}
+
+func testSorted(values ...string) []string {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func testStringSet(values ...string) []interface{} {
+	set := []interface{}{}
+	for _, value := range values {
+		set = append(set, value)
+	}
+
+	return set
+}
+
+func TestKeycloakOrganizationMember_exhaustive(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, users := testOrganizationWithUsers(t, 3)
+	config := func(userIds ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"realm":           testAccRealm.Realm,
+			"organization_id": organization.Id,
+			"user_ids":        testStringSet(userIds...),
+		}
+	}
+	members := func() []string {
+		members, err := keycloakClient.GetOrganizationMembers(testCtx, testAccRealm.Realm, organization.Id)
+		return testSortedUserIds(t, members, err)
+	}
+
+	state := testResourceApply(t, "keycloak_organization_member", nil, config(users[0], users[1]))
+	if expected := testSorted(users[0], users[1]); !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	// users that are removed from the configuration are removed from the organization
+	state = testResourceApply(t, "keycloak_organization_member", state, config(users[0]))
+	if expected := []string{users[0]}; !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	// members added outside of Terraform show up as drift, and are removed
+	if err := keycloakClient.AddUsersToOrganization(testCtx, testAccRealm.Realm, organization.Id, []string{users[2]}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state = testResourceRefresh(t, "keycloak_organization_member", state)
+	if expected := testSorted(users[0], users[2]); !reflect.DeepEqual(testStateSet(state, "user_ids"), expected) {
+		t.Fatalf("expected the refreshed user_ids to be %v, got %v", expected, testStateSet(state, "user_ids"))
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member", state, config(users[0]))
+	if expected := []string{users[0]}; !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	testResourceDestroy(t, "keycloak_organization_member", state)
+	if len(members()) != 0 {
+		t.Fatalf("expected the members to be removed, got %v", members())
+	}
+}
+
+func TestKeycloakOrganizationMember_notExhaustive(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, users := testOrganizationWithUsers(t, 3)
+	config := func(userIds ...string) map[string]interface{} {
+		return map[string]interface{}{
+			"realm":           testAccRealm.Realm,
+			"organization_id": organization.Id,
+			"user_ids":        testStringSet(userIds...),
+			"exhaustive":      false,
+		}
+	}
+	members := func() []string {
+		members, err := keycloakClient.GetOrganizationMembers(testCtx, testAccRealm.Realm, organization.Id)
+		return testSortedUserIds(t, members, err)
+	}
+
+	// members that aren't managed by the resource are left alone
+	if err := keycloakClient.AddUsersToOrganization(testCtx, testAccRealm.Realm, organization.Id, []string{users[2]}); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state := testResourceApply(t, "keycloak_organization_member", nil, config(users[0]))
+	if expected := testSorted(users[0], users[2]); !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	state = testResourceRefresh(t, "keycloak_organization_member", state)
+	if expected := []string{users[0]}; !reflect.DeepEqual(testStateSet(state, "user_ids"), expected) {
+		t.Fatalf("expected the refreshed user_ids to be %v, got %v", expected, testStateSet(state, "user_ids"))
+	}
+	if diff := testResourcePlan(t, "keycloak_organization_member", state, config(users[0])); !diff.Empty() {
+		t.Fatalf("expected no drift, got %v", diff)
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member", state, config(users[0], users[1]))
+	if expected := testSorted(users...); !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	state = testResourceApply(t, "keycloak_organization_member", state, config(users[1]))
+	if expected := testSorted(users[1], users[2]); !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected members %v, got %v", expected, members())
+	}
+
+	testResourceDestroy(t, "keycloak_organization_member", state)
+	if expected := []string{users[2]}; !reflect.DeepEqual(members(), expected) {
+		t.Fatalf("expected only the unmanaged member to be left, got %v", members())
+	}
+}
+
+func TestKeycloakOrganizationMember_import(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	organization, users := testOrganizationWithUsers(t, 2)
+	if err := keycloakClient.AddUsersToOrganization(testCtx, testAccRealm.Realm, organization.Id, users); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	state := testResourceImport(t, "keycloak_organization_member", testAccRealm.Realm+"/"+organization.Id)
+	if expected := testSorted(users...); !reflect.DeepEqual(testStateSet(state, "user_ids"), expected) {
+		t.Fatalf("expected the imported user_ids to be %v, got %v", expected, testStateSet(state, "user_ids"))
+	}
+
+	if diff := testResourcePlan(t, "keycloak_organization_member", state, map[string]interface{}{
+		"realm":           testAccRealm.Realm,
+		"organization_id": organization.Id,
+		"user_ids":        testStringSet(users...),
+	}); !diff.Empty() {
+		t.Fatalf("expected no changes after the import, got %v", diff)
+	}
+}
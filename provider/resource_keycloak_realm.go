@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -1373,7 +1374,7 @@ func resourceKeycloakRealmCreate(ctx context.Context, data *schema.ResourceData,
 
 	err = keycloakClient.NewRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, realmAttributePath)
 	}
 
 	setRealmData(data, realm)
@@ -1414,7 +1415,7 @@ func resourceKeycloakRealmUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err = keycloakClient.UpdateRealm(ctx, realm)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, realmAttributePath)
 	}
 
 	setRealmData(data, realm)
@@ -1425,5 +1426,15 @@ func resourceKeycloakRealmUpdate(ctx context.Context, data *schema.ResourceData,
 func resourceKeycloakRealmDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
+	err := keycloakClient.BackupRealm(ctx, data.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.FromErr(keycloakClient.DeleteRealm(ctx, data.Id()))
 }
+
+// maps the fields named in validation errors to the arguments of this resource
+func realmAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakRealm().Schema, nil)(field)
+}
@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientPolicies() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientPoliciesCreate,
+		ReadContext:   resourceKeycloakRealmClientPoliciesRead,
+		DeleteContext: resourceKeycloakRealmClientPoliciesDelete,
+		UpdateContext: resourceKeycloakRealmClientPoliciesUpdate,
+		CustomizeDiff: requireCapabilities(keycloak.CapabilityClientPolicies),
+		// This resource can be imported using the realm name
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientPoliciesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"condition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The provider ID of the condition, such as `client-roles` or `any-client`.",
+									},
+									"configuration": clientPolicyConfigurationSchema(),
+								},
+							},
+						},
+						"profiles": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Names of the client profiles applied to the clients matched by this policy.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getRealmClientPoliciesFromData(data *schema.ResourceData) (*keycloak.RealmClientPolicies, error) {
+	policies := make([]*keycloak.RealmClientPolicy, 0)
+
+	for _, p := range data.Get("policy").([]interface{}) {
+		policyData := p.(map[string]interface{})
+
+		policy := &keycloak.RealmClientPolicy{
+			Name:        policyData["name"].(string),
+			Description: policyData["description"].(string),
+			Enabled:     policyData["enabled"].(bool),
+			Conditions:  make([]*keycloak.RealmClientPolicyCondition, 0),
+			Profiles:    make([]string, 0),
+		}
+
+		for _, c := range policyData["condition"].([]interface{}) {
+			conditionData := c.(map[string]interface{})
+
+			configuration, err := getClientPolicyConfigurationFromData(conditionData["configuration"].(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid configuration for condition %s of client policy %s: %v", conditionData["name"], policy.Name, err)
+			}
+
+			policy.Conditions = append(policy.Conditions, &keycloak.RealmClientPolicyCondition{
+				Condition:     conditionData["name"].(string),
+				Configuration: configuration,
+			})
+		}
+
+		for _, profile := range policyData["profiles"].([]interface{}) {
+			policy.Profiles = append(policy.Profiles, profile.(string))
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return &keycloak.RealmClientPolicies{
+		Policies: policies,
+	}, nil
+}
+
+func setRealmClientPoliciesData(data *schema.ResourceData, realmClientPolicies *keycloak.RealmClientPolicies) error {
+	policies := make([]interface{}, 0)
+
+	for _, policy := range realmClientPolicies.Policies {
+		conditions := make([]interface{}, 0)
+
+		for _, condition := range policy.Conditions {
+			configuration, err := getClientPolicyConfigurationData(condition.Configuration)
+			if err != nil {
+				return err
+			}
+
+			conditions = append(conditions, map[string]interface{}{
+				"name":          condition.Condition,
+				"configuration": configuration,
+			})
+		}
+
+		policies = append(policies, map[string]interface{}{
+			"name":        policy.Name,
+			"description": policy.Description,
+			"enabled":     policy.Enabled,
+			"condition":   conditions,
+			"profiles":    policy.Profiles,
+		})
+	}
+
+	return data.Set("policy", policies)
+}
+
+func resourceKeycloakRealmClientPoliciesCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	data.SetId(realmId)
+
+	realmClientPolicies, err := getRealmClientPoliciesFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientPolicies(ctx, realmId, realmClientPolicies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientPoliciesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPoliciesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientPolicies, err := keycloakClient.GetRealmClientPolicies(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return diag.FromErr(setRealmClientPoliciesData(data, realmClientPolicies))
+}
+
+func resourceKeycloakRealmClientPoliciesUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientPolicies, err := getRealmClientPoliciesFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientPolicies(ctx, realmId, realmClientPolicies)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientPoliciesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientPoliciesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	// Client policies are owned by the realm, so removing this resource removes every policy from the realm.
+	realmClientPolicies := &keycloak.RealmClientPolicies{
+		Policies: []*keycloak.RealmClientPolicy{},
+	}
+
+	return diag.FromErr(keycloakClient.UpdateRealmClientPolicies(ctx, realmId, realmClientPolicies))
+}
+
+func resourceKeycloakRealmClientPoliciesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
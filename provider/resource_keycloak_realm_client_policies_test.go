@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientPolicies_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientPoliciesDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientPolicyEnabled("keycloak_realm_client_policies.policies", "pkce-for-public-clients", true),
+					resource.TestCheckResourceAttr("keycloak_realm_client_policies.policies", "policy.0.profiles.0", "enforce-pkce"),
+				),
+			},
+			{
+				Config: testKeycloakRealmClientPolicies_basic(realmName, false),
+				Check:  testAccCheckKeycloakRealmClientPolicyEnabled("keycloak_realm_client_policies.policies", "pkce-for-public-clients", false),
+			},
+			{
+				ResourceName:      "keycloak_realm_client_policies.policies",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     realmName,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientPolicyEnabled(resourceName, policyName string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+
+		realmClientPolicies, err := keycloakClient.GetRealmClientPolicies(testCtx, realm)
+		if err != nil {
+			return fmt.Errorf("error getting realm client policies: %s", err)
+		}
+
+		for _, policy := range realmClientPolicies.Policies {
+			if policy.Name == policyName {
+				if policy.Enabled != enabled {
+					return fmt.Errorf("expected client policy %s to have enabled set to %t, got %t", policyName, enabled, policy.Enabled)
+				}
+
+				return nil
+			}
+		}
+
+		return fmt.Errorf("client policy %s not found in realm %s", policyName, realm)
+	}
+}
+
+func testAccCheckKeycloakRealmClientPoliciesDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_policies" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+
+			realmClientPolicies, _ := keycloakClient.GetRealmClientPolicies(testCtx, realm)
+			if realmClientPolicies != nil && len(realmClientPolicies.Policies) != 0 {
+				return fmt.Errorf("client policies for realm %s still exist", realm)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmClientPolicies_basic(realm string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_profiles" "profiles" {
+	realm_id = keycloak_realm.realm.id
+
+	profile {
+		name = "enforce-pkce"
+
+		executor {
+			name          = "pkce-enforcer"
+			configuration = jsonencode({
+				"auto-configure" = "true"
+			})
+		}
+	}
+}
+
+resource "keycloak_realm_client_policies" "policies" {
+	realm_id = keycloak_realm_client_profiles.profiles.realm_id
+
+	policy {
+		name        = "pkce-for-public-clients"
+		description = "Require PKCE for public clients"
+		enabled     = %t
+
+		condition {
+			name          = "client-access-type"
+			configuration = jsonencode({
+				type = ["public"]
+			})
+		}
+
+		profiles = ["enforce-pkce"]
+	}
+}
+`, realm, enabled)
+}
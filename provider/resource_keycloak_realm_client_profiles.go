@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmClientProfiles() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmClientProfilesCreate,
+		ReadContext:   resourceKeycloakRealmClientProfilesRead,
+		DeleteContext: resourceKeycloakRealmClientProfilesDelete,
+		UpdateContext: resourceKeycloakRealmClientProfilesUpdate,
+		CustomizeDiff: requireCapabilities(keycloak.CapabilityClientPolicies),
+		// This resource can be imported using the realm name
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmClientProfilesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"profile": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"executor": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "The provider ID of the executor, such as `pkce-enforcer` or `secure-client-authenticator`.",
+									},
+									"configuration": clientPolicyConfigurationSchema(),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Executors and conditions are configured with arbitrary JSON documents, which depend on the executor or condition type
+func clientPolicyConfigurationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          "{}",
+		ValidateFunc:     validation.StringIsJSON,
+		DiffSuppressFunc: structure.SuppressJsonDiff,
+	}
+}
+
+func getClientPolicyConfigurationFromData(configuration string) (map[string]interface{}, error) {
+	if configuration == "" {
+		return map[string]interface{}{}, nil
+	}
+
+	return structure.ExpandJsonFromString(configuration)
+}
+
+func getClientPolicyConfigurationData(configuration map[string]interface{}) (string, error) {
+	if configuration == nil {
+		return "{}", nil
+	}
+
+	encoded, err := json.Marshal(configuration)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+func getRealmClientProfilesFromData(data *schema.ResourceData) (*keycloak.RealmClientProfiles, error) {
+	profiles := make([]*keycloak.RealmClientProfile, 0)
+
+	for _, p := range data.Get("profile").([]interface{}) {
+		profileData := p.(map[string]interface{})
+
+		profile := &keycloak.RealmClientProfile{
+			Name:        profileData["name"].(string),
+			Description: profileData["description"].(string),
+			Executors:   make([]*keycloak.RealmClientPolicyExecutor, 0),
+		}
+
+		for _, e := range profileData["executor"].([]interface{}) {
+			executorData := e.(map[string]interface{})
+
+			configuration, err := getClientPolicyConfigurationFromData(executorData["configuration"].(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid configuration for executor %s of client profile %s: %v", executorData["name"], profile.Name, err)
+			}
+
+			profile.Executors = append(profile.Executors, &keycloak.RealmClientPolicyExecutor{
+				Executor:      executorData["name"].(string),
+				Configuration: configuration,
+			})
+		}
+
+		profiles = append(profiles, profile)
+	}
+
+	return &keycloak.RealmClientProfiles{
+		Profiles: profiles,
+	}, nil
+}
+
+func setRealmClientProfilesData(data *schema.ResourceData, realmClientProfiles *keycloak.RealmClientProfiles) error {
+	profiles := make([]interface{}, 0)
+
+	for _, profile := range realmClientProfiles.Profiles {
+		executors := make([]interface{}, 0)
+
+		for _, executor := range profile.Executors {
+			configuration, err := getClientPolicyConfigurationData(executor.Configuration)
+			if err != nil {
+				return err
+			}
+
+			executors = append(executors, map[string]interface{}{
+				"name":          executor.Executor,
+				"configuration": configuration,
+			})
+		}
+
+		profiles = append(profiles, map[string]interface{}{
+			"name":        profile.Name,
+			"description": profile.Description,
+			"executor":    executors,
+		})
+	}
+
+	return data.Set("profile", profiles)
+}
+
+func resourceKeycloakRealmClientProfilesCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	data.SetId(realmId)
+
+	realmClientProfiles, err := getRealmClientProfilesFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientProfiles(ctx, realmId, realmClientProfiles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientProfilesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientProfilesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientProfiles, err := keycloakClient.GetRealmClientProfiles(ctx, realmId)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return diag.FromErr(setRealmClientProfilesData(data, realmClientProfiles))
+}
+
+func resourceKeycloakRealmClientProfilesUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	realmClientProfiles, err := getRealmClientProfilesFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = keycloakClient.UpdateRealmClientProfiles(ctx, realmId, realmClientProfiles)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceKeycloakRealmClientProfilesRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmClientProfilesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+
+	// Client profiles are owned by the realm, so removing this resource removes every profile from the realm.
+	realmClientProfiles := &keycloak.RealmClientProfiles{
+		Profiles: []*keycloak.RealmClientProfile{},
+	}
+
+	return diag.FromErr(keycloakClient.UpdateRealmClientProfiles(ctx, realmId, realmClientProfiles))
+}
+
+func resourceKeycloakRealmClientProfilesImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	d.Set("realm_id", d.Id())
+
+	return []*schema.ResourceData{d}, nil
+}
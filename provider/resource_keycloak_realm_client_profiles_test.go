@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmClientProfiles_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_14)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmClientProfilesDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmClientProfiles_basic(realmName, "pkce-enforcer", `{"auto-configure": "true"}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmClientProfilesHasExecutor("keycloak_realm_client_profiles.profiles", "enforce-pkce", "pkce-enforcer"),
+					resource.TestCheckResourceAttr("keycloak_realm_client_profiles.profiles", "profile.0.executor.0.name", "pkce-enforcer"),
+				),
+			},
+			{
+				Config: testKeycloakRealmClientProfiles_basic(realmName, "secure-client-authenticator", `{"allowed-client-authenticators": ["client-jwt"], "default-client-authenticator": "client-jwt"}`),
+				Check:  testAccCheckKeycloakRealmClientProfilesHasExecutor("keycloak_realm_client_profiles.profiles", "enforce-pkce", "secure-client-authenticator"),
+			},
+			{
+				ResourceName:      "keycloak_realm_client_profiles.profiles",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     realmName,
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmClientProfilesHasExecutor(resourceName, profileName, executor string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+
+		realmClientProfiles, err := keycloakClient.GetRealmClientProfiles(testCtx, realm)
+		if err != nil {
+			return fmt.Errorf("error getting realm client profiles: %s", err)
+		}
+
+		for _, profile := range realmClientProfiles.Profiles {
+			if profile.Name != profileName {
+				continue
+			}
+
+			for _, e := range profile.Executors {
+				if e.Executor == executor {
+					return nil
+				}
+			}
+		}
+
+		return fmt.Errorf("expected client profile %s in realm %s to have executor %s", profileName, realm, executor)
+	}
+}
+
+func testAccCheckKeycloakRealmClientProfilesDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_client_profiles" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+
+			realmClientProfiles, _ := keycloakClient.GetRealmClientProfiles(testCtx, realm)
+			if realmClientProfiles != nil && len(realmClientProfiles.Profiles) != 0 {
+				return fmt.Errorf("client profiles for realm %s still exist", realm)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmClientProfiles_basic(realm, executor, configuration string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_client_profiles" "profiles" {
+	realm_id = keycloak_realm.realm.id
+
+	profile {
+		name        = "enforce-pkce"
+		description = "Require PKCE for every client"
+
+		executor {
+			name          = "%s"
+			configuration = jsonencode(%s)
+		}
+	}
+}
+`, realm, executor, configuration)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func resourceKeycloakRealmLocalization() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmLocalizationReconcile,
+		ReadContext:   resourceKeycloakRealmLocalizationRead,
+		DeleteContext: resourceKeycloakRealmLocalizationDelete,
+		UpdateContext: resourceKeycloakRealmLocalizationReconcile,
+		CustomizeDiff: requireCapabilities(keycloak.CapabilityRealmLocalization),
+		// This resource can be imported using {{realm}}/{{locale}}.
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKeycloakRealmLocalizationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"locale": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"texts": {
+				Type:        schema.TypeMap,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Required:    true,
+				Description: "Message keys and the texts that override them for this locale.",
+			},
+			"exhaustive": {
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+				Description: "When true, texts of the locale that are not in the texts map are removed from the realm.",
+			},
+		},
+	}
+}
+
+func getRealmLocalizationTextsFromData(data *schema.ResourceData) map[string]string {
+	texts := map[string]string{}
+	for key, text := range data.Get("texts").(map[string]interface{}) {
+		texts[key] = text.(string)
+	}
+
+	return texts
+}
+
+func resourceKeycloakRealmLocalizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+	exhaustive := data.Get("exhaustive").(bool)
+	managedTexts := getRealmLocalizationTextsFromData(data)
+
+	realmTexts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale)
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	texts := map[string]string{}
+	for key, text := range realmTexts {
+		//only add texts that we care about
+		if _, ok := managedTexts[key]; exhaustive || ok {
+			texts[key] = text
+		}
+	}
+
+	data.Set("texts", texts)
+	data.SetId(realmLocalizationId(realmId, locale))
+
+	return nil
+}
+
+func resourceKeycloakRealmLocalizationReconcile(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+	exhaustive := data.Get("exhaustive").(bool)
+	texts := getRealmLocalizationTextsFromData(data)
+
+	var remove []string
+
+	if data.HasChange("texts") {
+		o, _ := data.GetChange("texts")
+		for key := range o.(map[string]interface{}) {
+			if _, ok := texts[key]; !ok {
+				remove = append(remove, key)
+			}
+		}
+	}
+
+	if len(texts) != 0 {
+		if err := keycloakClient.UpdateRealmLocalizationTexts(ctx, realmId, locale, texts); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if exhaustive {
+		realmTexts, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for key := range realmTexts {
+			if _, ok := texts[key]; !ok && !stringSliceContains(remove, key) {
+				remove = append(remove, key)
+			}
+		}
+	}
+
+	sort.Strings(remove)
+	for _, key := range remove {
+		if err := keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key); err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	data.SetId(realmLocalizationId(realmId, locale))
+	return resourceKeycloakRealmLocalizationRead(ctx, data, meta)
+}
+
+func resourceKeycloakRealmLocalizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	locale := data.Get("locale").(string)
+
+	if data.Get("exhaustive").(bool) {
+		return diag.FromErr(keycloakClient.DeleteRealmLocalizationTexts(ctx, realmId, locale))
+	}
+
+	for key := range getRealmLocalizationTextsFromData(data) {
+		if err := keycloakClient.DeleteRealmLocalizationText(ctx, realmId, locale, key); err != nil && !keycloak.ErrorIs404(err) {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmLocalizationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{locale}}.")
+	}
+
+	realmId := parts[0]
+	locale := parts[1]
+
+	_, err := keycloakClient.GetRealmLocalizationTexts(ctx, realmId, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.Set("locale", locale)
+	d.Set("exhaustive", true)
+
+	diagnostics := resourceKeycloakRealmLocalizationRead(ctx, d, meta)
+	if diagnostics.HasError() {
+		return nil, errors.New(diagnostics[0].Summary)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func realmLocalizationId(realmId, locale string) string {
+	return fmt.Sprintf("%s/%s", realmId, locale)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+func TestAccKeycloakRealmLocalization_basic(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_12)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmLocalizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, "Sign in", true),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.texts", map[string]string{"loginTitle": "Sign in", "doLogIn": "Log in"}),
+			},
+			{
+				// texts that are added outside of terraform are removed again
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmLocalizationTexts(testCtx, realmName, "en", map[string]string{"doCancel": "Abort"})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmLocalization_basic(realmName, "Welcome", true),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.texts", map[string]string{"loginTitle": "Welcome", "doLogIn": "Log in"}),
+			},
+			{
+				ResourceName:      "keycloak_realm_localization.texts",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     realmName + "/en",
+			},
+		},
+	})
+}
+
+func TestAccKeycloakRealmLocalization_notExhaustive(t *testing.T) {
+	skipIfVersionIsLessThanOrEqualTo(testCtx, t, keycloakClient, keycloak.Version_12)
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		CheckDestroy:      testAccCheckKeycloakRealmLocalizationDestroy(),
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmLocalization_basic(realmName, "Sign in", false),
+			},
+			{
+				// texts that are added outside of terraform are left alone, changed texts are reverted
+				PreConfig: func() {
+					err := keycloakClient.UpdateRealmLocalizationTexts(testCtx, realmName, "en", map[string]string{"doCancel": "Abort", "loginTitle": "Changed"})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testKeycloakRealmLocalization_basic(realmName, "Sign in", false),
+				Check:  testAccCheckKeycloakRealmLocalizationTexts("keycloak_realm_localization.texts", map[string]string{"loginTitle": "Sign in", "doLogIn": "Log in", "doCancel": "Abort"}),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmLocalizationTexts(resourceName string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+		locale := rs.Primary.Attributes["locale"]
+
+		texts, err := keycloakClient.GetRealmLocalizationTexts(testCtx, realm, locale)
+		if err != nil {
+			return fmt.Errorf("error getting localization texts: %s", err)
+		}
+
+		if len(texts) != len(expected) {
+			return fmt.Errorf("expected %d localization texts, got %v", len(expected), texts)
+		}
+
+		for key, text := range expected {
+			if texts[key] != text {
+				return fmt.Errorf("expected localization text %s to be %s, got %s", key, text, texts[key])
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckKeycloakRealmLocalizationDestroy() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "keycloak_realm_localization" {
+				continue
+			}
+
+			realm := rs.Primary.Attributes["realm_id"]
+			locale := rs.Primary.Attributes["locale"]
+
+			texts, _ := keycloakClient.GetRealmLocalizationTexts(testCtx, realm, locale)
+			if len(texts) != 0 {
+				return fmt.Errorf("localization texts for locale %s of realm %s still exist", locale, realm)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmLocalization_basic(realm, loginTitle string, exhaustive bool) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_localization" "texts" {
+	realm_id   = keycloak_realm.realm.id
+	locale     = "en"
+	exhaustive = %t
+
+	texts = {
+		loginTitle = "%s"
+		doLogIn    = "Log in"
+	}
+}
+	`, realm, exhaustive, loginTitle)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
+)
+
+// A partial import can't be read back or undone, so this resource only records the result of the import. Changing it
+// imports the representation again, and destroying it leaves the imported resources in the realm.
+func resourceKeycloakRealmPartialImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKeycloakRealmPartialImportCreate,
+		ReadContext:   resourceKeycloakRealmPartialImportRead,
+		DeleteContext: resourceKeycloakRealmPartialImportDelete,
+		Schema: map[string]*schema.Schema{
+			"realm_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "A JSON encoded realm representation with the users, clients, groups, roles and identity providers to import.",
+			},
+			"if_resource_exists": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "FAIL",
+				ValidateFunc: validation.StringInSlice([]string{"FAIL", "SKIP", "OVERWRITE"}, false),
+			},
+			"added": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"skipped": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"overwritten": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceKeycloakRealmPartialImportCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	realmId := data.Get("realm_id").(string)
+	representationJson := data.Get("json").(string)
+	ifResourceExists := data.Get("if_resource_exists").(string)
+
+	var representation map[string]interface{}
+	if err := json.Unmarshal([]byte(representationJson), &representation); err != nil {
+		return diag.Errorf("json must be a JSON encoded realm representation: %v", err)
+	}
+
+	response, err := keycloakClient.PartialImportRealm(ctx, realmId, representation, ifResourceExists)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var results []interface{}
+	for _, result := range response.Results {
+		results = append(results, map[string]interface{}{
+			"action":        result.Action,
+			"resource_type": result.ResourceType,
+			"resource_name": result.ResourceName,
+			"id":            result.Id,
+		})
+	}
+
+	data.SetId(realmPartialImportId(realmId, representationJson))
+	data.Set("added", response.Added)
+	data.Set("skipped", response.Skipped)
+	data.Set("overwritten", response.Overwritten)
+	data.Set("results", results)
+
+	return nil
+}
+
+func resourceKeycloakRealmPartialImportRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keycloakClient := meta.(*keycloak.KeycloakClient)
+
+	_, err := keycloakClient.GetRealm(ctx, data.Get("realm_id").(string))
+	if err != nil {
+		return handleNotFoundError(ctx, err, data)
+	}
+
+	return nil
+}
+
+func resourceKeycloakRealmPartialImportDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func realmPartialImportId(realmId, representationJson string) string {
+	sum := sha1.Sum([]byte(representationJson))
+
+	return fmt.Sprintf("%s/%s", realmId, hex.EncodeToString(sum[:]))
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeycloakRealmPartialImport_basic(t *testing.T) {
+	t.Parallel()
+
+	realmName := acctest.RandomWithPrefix("tf-acc")
+	groupName := acctest.RandomWithPrefix("tf-acc")
+	roleName := acctest.RandomWithPrefix("tf-acc")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		PreCheck:          func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testKeycloakRealmPartialImport_basic(realmName, groupName, roleName, "FAIL"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckKeycloakRealmPartialImportImported("keycloak_realm_partial_import.import", groupName, roleName),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "added", "2"),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "results.#", "2"),
+				),
+			},
+			{
+				// importing the same resources again skips them
+				Config: testKeycloakRealmPartialImport_basic(realmName, groupName, roleName, "SKIP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "added", "0"),
+					resource.TestCheckResourceAttr("keycloak_realm_partial_import.import", "skipped", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckKeycloakRealmPartialImportImported(resourceName, groupName, roleName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		realm := rs.Primary.Attributes["realm_id"]
+
+		if _, err := keycloakClient.GetGroupByName(testCtx, realm, groupName); err != nil {
+			return fmt.Errorf("error getting imported group %s: %s", groupName, err)
+		}
+
+		if _, err := keycloakClient.GetRoleByName(testCtx, realm, "", roleName); err != nil {
+			return fmt.Errorf("error getting imported role %s: %s", roleName, err)
+		}
+
+		return nil
+	}
+}
+
+func testKeycloakRealmPartialImport_basic(realm, group, role, ifResourceExists string) string {
+	return fmt.Sprintf(`
+resource "keycloak_realm" "realm" {
+	realm = "%s"
+}
+
+resource "keycloak_realm_partial_import" "import" {
+	realm_id           = keycloak_realm.realm.id
+	if_resource_exists = "%s"
+
+	json = jsonencode({
+		groups = [
+			{
+				name = "%s"
+			}
+		]
+		roles = {
+			realm = [
+				{
+					name = "%s"
+				}
+			]
+		}
+	})
+}
+	`, realm, ifResourceExists, group, role)
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -16,6 +17,7 @@ func resourceKeycloakRealmUserProfile() *schema.Resource {
 		ReadContext:   resourceKeycloakRealmUserProfileRead,
 		DeleteContext: resourceKeycloakRealmUserProfileDelete,
 		UpdateContext: resourceKeycloakRealmUserProfileUpdate,
+		CustomizeDiff: requireCapabilities(keycloak.CapabilityUserProfile),
 		Schema: map[string]*schema.Schema{
 			"realm_id": {
 				Type:     schema.TypeString,
@@ -402,6 +404,26 @@ func setRealmUserProfileData(data *schema.ResourceData, realmUserProfile *keyclo
 	data.Set("group", groups)
 }
 
+// validation errors for the user profile name the attribute or group that is invalid
+func realmUserProfileAttributePath(realmUserProfile *keycloak.RealmUserProfile) func(string) cty.Path {
+	return func(field string) cty.Path {
+		for i, attribute := range realmUserProfile.Attributes {
+			if attribute.Name == field {
+				return cty.GetAttrPath("attribute").IndexInt(i)
+			}
+		}
+
+		// groups are a set, so the error can only point at the whole block
+		for _, group := range realmUserProfile.Groups {
+			if group.Name == field {
+				return cty.GetAttrPath("group")
+			}
+		}
+
+		return nil
+	}
+}
+
 func resourceKeycloakRealmUserProfileCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 	realmId := data.Get("realm_id").(string)
@@ -411,7 +433,7 @@ func resourceKeycloakRealmUserProfileCreate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmUserProfile(ctx, realmId, realmUserProfile)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, realmUserProfileAttributePath(realmUserProfile))
 	}
 
 	return resourceKeycloakRealmUserProfileRead(ctx, data, meta)
@@ -458,7 +480,7 @@ func resourceKeycloakRealmUserProfileUpdate(ctx context.Context, data *schema.Re
 
 	err := keycloakClient.UpdateRealmUserProfile(ctx, realmId, realmUserProfile)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, realmUserProfileAttributePath(realmUserProfile))
 	}
 
 	setRealmUserProfileData(data, realmUserProfile)
@@ -243,18 +243,23 @@ func resourceKeycloakRoleDelete(ctx context.Context, data *schema.ResourceData,
 func resourceKeycloakRoleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import format: {{realm}}/{{roleId}}.")
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realm}}/{{roleId}}, {{realm}}/{{roleName}}, {{realm}}/client/{{clientId}}/role/{{roleName}}.")
 	}
 
-	_, err := keycloakClient.GetRole(ctx, parts[0], parts[1])
+	id, err := resolveRoleImportKey(ctx, keycloakClient, realmId, key)
 	if err != nil {
 		return nil, err
 	}
 
-	d.Set("realm_id", parts[0])
-	d.SetId(parts[1])
+	_, err = keycloakClient.GetRole(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
 
 	diagnostics := resourceKeycloakRoleRead(ctx, d, meta)
 	if diagnostics.HasError() {
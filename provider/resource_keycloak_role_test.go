@@ -29,6 +29,12 @@ func TestAccKeycloakRole_basicRealm(t *testing.T) {
 				ImportStateVerify:   true,
 				ImportStateIdPrefix: testAccRealm.Realm + "/",
 			},
+			{
+				ResourceName:      "keycloak_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/" + roleName,
+			},
 		},
 	})
 }
@@ -76,6 +82,12 @@ func TestAccKeycloakRole_basicClient(t *testing.T) {
 				ImportStateVerify:   true,
 				ImportStateIdPrefix: testAccRealm.Realm + "/",
 			},
+			{
+				ResourceName:      "keycloak_role.role",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccRealm.Realm + "/client/" + clientId + "/role/" + roleName,
+			},
 		},
 	})
 }
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak/types"
@@ -417,7 +418,7 @@ func resourceKeycloakSamlClientCreate(ctx context.Context, data *schema.Resource
 
 	err := keycloakClient.NewSamlClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, samlClientAttributePath)
 	}
 
 	data.SetId(client.Id)
@@ -451,7 +452,7 @@ func resourceKeycloakSamlClientUpdate(ctx context.Context, data *schema.Resource
 
 	err := keycloakClient.UpdateSamlClient(ctx, client)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, samlClientAttributePath)
 	}
 
 	err = mapToDataFromSamlClient(ctx, data, client)
@@ -468,24 +469,34 @@ func resourceKeycloakSamlClientDelete(ctx context.Context, data *schema.Resource
 	realmId := data.Get("realm_id").(string)
 	id := data.Id()
 
+	err := keycloakClient.BackupClient(ctx, realmId, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diag.FromErr(keycloakClient.DeleteSamlClient(ctx, realmId, id))
 }
 
 func resourceKeycloakSamlClientImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{samlClientId}}")
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{samlClientId}}, {{realmId}}/{{clientId}}")
 	}
 
-	_, err := keycloakClient.GetSamlClient(ctx, parts[0], parts[1])
+	id, err := resolveClientImportKey(ctx, keycloakClient, realmId, key)
 	if err != nil {
 		return nil, err
 	}
 
-	d.Set("realm_id", parts[0])
-	d.SetId(parts[1])
+	_, err = keycloakClient.GetSamlClient(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
 
 	diagnostics := resourceKeycloakSamlClientRead(ctx, d, meta)
 	if diagnostics.HasError() {
@@ -494,3 +505,12 @@ func resourceKeycloakSamlClientImport(ctx context.Context, d *schema.ResourceDat
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// maps the fields named in validation errors to the arguments of this resource
+func samlClientAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakSamlClient().Schema, map[string]string{
+		"redirectUris":       "valid_redirect_uris",
+		"adminUrl":           "master_saml_processing_url",
+		"frontchannelLogout": "front_channel_logout",
+	})(field)
+}
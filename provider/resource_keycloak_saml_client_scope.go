@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"strconv"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -94,7 +94,7 @@ func resourceKeycloakSamlClientScopeCreate(ctx context.Context, data *schema.Res
 
 	err := keycloakClient.NewSamlClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, samlClientScopeAttributePath)
 	}
 
 	setSamlClientScopeData(data, clientScope)
@@ -125,7 +125,7 @@ func resourceKeycloakSamlClientScopeUpdate(ctx context.Context, data *schema.Res
 
 	err := keycloakClient.UpdateSamlClientScope(ctx, clientScope)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, samlClientScopeAttributePath)
 	}
 
 	setSamlClientScopeData(data, clientScope)
@@ -145,18 +145,23 @@ func resourceKeycloakSamlClientScopeDelete(ctx context.Context, data *schema.Res
 func resourceKeycloakSamlClientScopeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
-	parts := strings.Split(d.Id(), "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{samlClientScopeId}}")
+	realmId, key, ok := splitRealmImportId(d.Id())
+	if !ok {
+		return nil, fmt.Errorf("Invalid import. Supported import formats: {{realmId}}/{{samlClientScopeId}}, {{realmId}}/{{samlClientScopeName}}")
 	}
 
-	_, err := keycloakClient.GetSamlClientScope(ctx, parts[0], parts[1])
+	id, err := resolveClientScopeImportKey(ctx, keycloakClient, realmId, "saml", key)
 	if err != nil {
 		return nil, err
 	}
 
-	d.Set("realm_id", parts[0])
-	d.SetId(parts[1])
+	_, err = keycloakClient.GetSamlClientScope(ctx, realmId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("realm_id", realmId)
+	d.SetId(id)
 
 	diagnostics := resourceKeycloakSamlClientScopeRead(ctx, d, meta)
 	if diagnostics.HasError() {
@@ -165,3 +170,8 @@ func resourceKeycloakSamlClientScopeImport(ctx context.Context, d *schema.Resour
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// maps the fields named in validation errors to the arguments of this resource
+func samlClientScopeAttributePath(field string) cty.Path {
+	return schemaAttributePath(resourceKeycloakSamlClientScope().Schema, nil)(field)
+}
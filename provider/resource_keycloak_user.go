@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -191,7 +192,7 @@ func resourceKeycloakUserCreate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.NewUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, userAttributePath)
 	}
 
 	v, isInitialPasswordSet := data.GetOk("initial_password")
@@ -233,7 +234,7 @@ func resourceKeycloakUserUpdate(ctx context.Context, data *schema.ResourceData,
 
 	err := keycloakClient.UpdateUser(ctx, user)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(err, userAttributePath)
 	}
 
 	mapFromUserToData(data, user)
@@ -241,6 +242,24 @@ func resourceKeycloakUserUpdate(ctx context.Context, data *schema.ResourceData,
 	return nil
 }
 
+// maps the user attributes named in user profile validation errors to the arguments of this resource
+func userAttributePath(field string) cty.Path {
+	switch field {
+	case "username":
+		return cty.GetAttrPath("username")
+	case "email":
+		return cty.GetAttrPath("email")
+	case "firstName":
+		return cty.GetAttrPath("first_name")
+	case "lastName":
+		return cty.GetAttrPath("last_name")
+	case "":
+		return nil
+	}
+
+	return cty.GetAttrPath("attributes").IndexString(field)
+}
+
 func resourceKeycloakUserDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keycloakClient := meta.(*keycloak.KeycloakClient)
 
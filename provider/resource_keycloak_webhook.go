@@ -29,17 +29,19 @@ func resourceKeycloakWebhook() *schema.Resource {
 				ForceNew: true,
 			},
 			"enabled": {
-				Type: schema.TypeBool,
+				Type:     schema.TypeBool,
 				Optional: true,
-				Default: true,
+				Default:  true,
 			},
 			"url": {
-				Type: schema.TypeString,
+				Type:     schema.TypeString,
 				Required: true,
 			},
+			// Keycloak never returns the secret, so it is only ever read from the configuration
 			"secret": {
-				Type: schema.TypeString,
-				Required: true,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
 			},
 			"event_types": {
 				Type:     schema.TypeSet,
@@ -47,6 +49,12 @@ func resourceKeycloakWebhook() *schema.Resource {
 				MinItems: 1,
 				Required: true,
 			},
+			"test_on_create": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "When true, a test event is sent to the webhook after it is created, and the apply fails if the endpoint rejects it.",
+			},
 		},
 	}
 }
@@ -61,9 +69,9 @@ func mapFromDataToWebhook(data *schema.ResourceData) *keycloak.Webhook {
 
 	webhook := &keycloak.Webhook{
 		Id:         data.Id(),
-		Enabled: 	data.Get("enabled").(bool),
+		Enabled:    data.Get("enabled").(bool),
 		URL:        data.Get("url").(string),
-		Secret:   	data.Get("secret").(string),
+		Secret:     data.Get("secret").(string),
 		EventTypes: eventTypes,
 		RealmName:  data.Get("realm").(string),
 	}
@@ -75,7 +83,6 @@ func mapFromWebhookToData(data *schema.ResourceData, webhook *keycloak.Webhook)
 	data.SetId(webhook.Id)
 	data.Set("enabled", webhook.Enabled)
 	data.Set("url", webhook.URL)
-	data.Set("secret", webhook.Secret)
 	data.Set("event_types", webhook.EventTypes)
 	data.Set("realm", webhook.RealmName)
 }
@@ -92,6 +99,13 @@ func resourceKeycloakWebhookCreate(ctx context.Context, data *schema.ResourceDat
 
 	mapFromWebhookToData(data, webhook)
 
+	if data.Get("test_on_create").(bool) {
+		err = keycloakClient.TestWebhook(ctx, webhook.RealmName, webhook.Id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceKeycloakWebhookRead(ctx, data, meta)
 }
 
@@ -149,6 +163,7 @@ func resourceKeycloakWebhookImport(ctx context.Context, d *schema.ResourceData,
 	}
 
 	d.Set("realm", parts[0])
+	d.Set("test_on_create", false)
 	d.SetId(parts[1])
 
 	diagnostics := resourceKeycloakWebhookRead(ctx, d, meta)
@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testWebhookEndpoint(t *testing.T, status int) string {
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(endpoint.Close)
+
+	return endpoint.URL
+}
+
+func testWebhookConfig(url string) map[string]interface{} {
+	return map[string]interface{}{
+		"realm":          testAccRealm.Realm,
+		"url":            url,
+		"secret":         "shh",
+		"event_types":    testStringSet("access.REGISTER"),
+		"test_on_create": true,
+	}
+}
+
+func TestKeycloakWebhook_testOnCreate(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	accepted := testResourceApply(t, "keycloak_webhook", nil, testWebhookConfig(testWebhookEndpoint(t, http.StatusAccepted)))
+	defer testResourceDestroy(t, "keycloak_webhook", accepted)
+
+	// the secret isn't returned by Keycloak, so it is kept from the configuration
+	if accepted.Attributes["secret"] != "shh" {
+		t.Fatalf("expected the secret to be kept in the state, got %v", accepted.Attributes)
+	}
+	if diff := testResourcePlan(t, "keycloak_webhook", testResourceRefresh(t, "keycloak_webhook", accepted), testWebhookConfig(accepted.Attributes["url"])); !diff.Empty() {
+		t.Fatalf("expected no changes after the webhook was created, got %v", diff)
+	}
+
+	rejected, diags := testResourceApplyError(t, "keycloak_webhook", nil, testWebhookConfig(testWebhookEndpoint(t, http.StatusForbidden)))
+	if rejected != nil && rejected.ID != "" {
+		defer testResourceDestroy(t, "keycloak_webhook", rejected)
+	}
+	if !strings.Contains(diags[0].Summary, "rejected the test event with HTTP status 403") {
+		t.Fatalf("unexpected error %v", diags[0].Summary)
+	}
+}
+
+func TestKeycloakWebhookSends(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	webhook := testResourceApply(t, "keycloak_webhook", nil, testWebhookConfig(testWebhookEndpoint(t, http.StatusOK)))
+	defer testResourceDestroy(t, "keycloak_webhook", webhook)
+
+	if err := keycloakClient.TestWebhook(testCtx, testAccRealm.Realm, webhook.ID); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	sends := testDataSourceRead(t, "keycloak_webhook_sends", map[string]interface{}{
+		"realm":      testAccRealm.Realm,
+		"webhook_id": webhook.ID,
+	})
+	if sends.Attributes["sends.#"] != "2" || sends.Attributes["sends.0.status"] != "200" || sends.Attributes["sends.0.event_type"] != "test" {
+		t.Fatalf("expected both test events to be listed with their HTTP status, got %v", sends.Attributes)
+	}
+	if sends.Attributes["sends.0.event_id"] == sends.Attributes["sends.1.event_id"] || sends.Attributes["sends.0.sent_at"] == "" {
+		t.Fatalf("unexpected sends %v", sends.Attributes)
+	}
+
+	sends = testDataSourceRead(t, "keycloak_webhook_sends", map[string]interface{}{
+		"realm":       testAccRealm.Realm,
+		"webhook_id":  webhook.ID,
+		"max_results": 1,
+	})
+	if sends.Attributes["sends.#"] != "1" {
+		t.Fatalf("expected max_results to limit the sends, got %v", sends.Attributes)
+	}
+}
+
+func TestKeycloakDataSourceWebhook(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	webhook := testResourceApply(t, "keycloak_webhook", nil, testWebhookConfig(testWebhookEndpoint(t, http.StatusOK)))
+	defer testResourceDestroy(t, "keycloak_webhook", webhook)
+
+	state := testDataSourceRead(t, "keycloak_webhook", map[string]interface{}{
+		"realm": testAccRealm.Realm,
+		"id":    webhook.ID,
+	})
+	if state.Attributes["url"] != webhook.Attributes["url"] || state.Attributes["enabled"] != "true" || state.Attributes["event_types.#"] != "1" {
+		t.Fatalf("unexpected webhook %v", state.Attributes)
+	}
+}
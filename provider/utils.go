@@ -2,9 +2,14 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/mrparkers/terraform-provider-keycloak/keycloak"
@@ -79,6 +84,94 @@ func handleNotFoundError(ctx context.Context, err error, data *schema.ResourceDa
 	return diag.FromErr(err)
 }
 
+// apiErrorDiagnostics turns an error returned by Keycloak into diagnostics. When Keycloak reports which fields are invalid,
+// attributePath is used to find the argument of the resource that each field comes from, so that Terraform can point at
+// it. attributePath returns nil for fields that don't map to an argument.
+func apiErrorDiagnostics(err error, attributePath func(field string) cty.Path) diag.Diagnostics {
+	apiError, ok := keycloak.AsApiError(err)
+	if !ok || len(apiError.FieldErrors) == 0 {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, fieldError := range apiError.FieldErrors {
+		summary := fieldError.ErrorMessage
+		if len(fieldError.Params) != 0 {
+			summary = fmt.Sprintf("%s (%s)", summary, strings.Join(fieldError.Params, ", "))
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid value for %s: %s", fieldError.Field, summary),
+			Detail:        apiError.Message,
+			AttributePath: attributePath(fieldError.Field),
+		})
+	}
+
+	return diags
+}
+
+// schemaAttributePath returns an attributePath for apiErrorDiagnostics that maps the fields of a Keycloak representation to
+// the arguments of the resource with the same name in snake case, such as rootUrl to root_url. renamed maps the fields
+// whose argument is named differently.
+func schemaAttributePath(resourceSchema map[string]*schema.Schema, renamed map[string]string) func(field string) cty.Path {
+	return func(field string) cty.Path {
+		name, ok := renamed[field]
+		if !ok {
+			name = fieldToArgumentName(field)
+		}
+
+		if _, ok := resourceSchema[name]; !ok {
+			return nil
+		}
+
+		return cty.GetAttrPath(name)
+	}
+}
+
+// fieldToArgumentName converts the name of a field in camel case, or of an attribute separated by dots, to snake case.
+// Acronyms are kept as a single word, so usernameLDAPAttribute becomes username_ldap_attribute.
+func fieldToArgumentName(field string) string {
+	runes := []rune(field)
+
+	var name strings.Builder
+	for i, r := range runes {
+		if r == '.' || r == '-' {
+			name.WriteRune('_')
+			continue
+		}
+
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1]))
+			if startsWord && runes[i-1] != '.' && runes[i-1] != '-' {
+				name.WriteRune('_')
+			}
+
+			r = unicode.ToLower(r)
+		}
+
+		name.WriteRune(r)
+	}
+
+	return name.String()
+}
+
+// requireCapabilities fails the plan with an error naming the missing capability, instead of letting the apply fail with
+// a 404 from an endpoint that the server doesn't have
+func requireCapabilities(capabilities ...keycloak.Capability) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, _ *schema.ResourceDiff, meta interface{}) error {
+		keycloakClient := meta.(*keycloak.KeycloakClient)
+
+		for _, capability := range capabilities {
+			if err := keycloakClient.RequireCapability(ctx, capability); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
 func interfaceSliceToStringSlice(iv []interface{}) []string {
 	var sv []string
 	for _, i := range iv {
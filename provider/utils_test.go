@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+)
+
+func TestFieldToArgumentName(t *testing.T) {
+	for field, expected := range map[string]string{
+		"rootUrl":                   "root_url",
+		"usernameLDAPAttribute":     "username_ldap_attribute",
+		"post.logout.redirect.uris": "post_logout_redirect_uris",
+		"realm":                     "realm",
+	} {
+		if actual := fieldToArgumentName(field); actual != expected {
+			t.Errorf("expected %s to be converted to %s, got %s", field, expected, actual)
+		}
+	}
+}
+
+func TestApiErrorDiagnosticsAttributePath(t *testing.T) {
+	testRequireFakeKeycloak(t)
+
+	// reject clients like Keycloak rejects invalid urls
+	testFakeKeycloak.SetValidator(func(method, path string, body map[string]interface{}) (string, string) {
+		if !strings.HasSuffix(path, "/clients") || method != http.MethodPost {
+			return "", ""
+		}
+
+		if body["rootUrl"] == "javascript:alert(1)" {
+			return "rootUrl", "invalid-url"
+		}
+		if redirectUris, _ := body["redirectUris"].([]interface{}); len(redirectUris) != 0 && redirectUris[0] == "javascript:alert(1)" {
+			return "redirectUris", "invalid-url"
+		}
+
+		return "", ""
+	})
+	defer testFakeKeycloak.SetValidator(nil)
+
+	for argument, value := range map[string]interface{}{
+		"root_url":            "javascript:alert(1)",
+		"valid_redirect_uris": testStringSet("javascript:alert(1)"),
+	} {
+		config := map[string]interface{}{
+			"realm_id":              testAccRealm.Realm,
+			"client_id":             acctest.RandomWithPrefix("tf-acc"),
+			"access_type":           "CONFIDENTIAL",
+			"standard_flow_enabled": true,
+			"valid_redirect_uris":   testStringSet("https://example.com/*"),
+		}
+		config[argument] = value
+
+		_, diags := testResourceApplyError(t, "keycloak_openid_client", nil, config)
+
+		if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath(argument)) {
+			t.Fatalf("expected an error for %s, got %v", argument, diags)
+		}
+		if !strings.Contains(diags[0].Summary, "invalid-url") {
+			t.Errorf("expected the error to describe the invalid value, got %s", diags[0].Summary)
+		}
+	}
+}